@@ -4,15 +4,46 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 )
 
+// probePaths maps a probe type to the endpoint checked on the base URL,
+// following the Kubernetes /livez and /readyz conventions.
+var probePaths = map[string]string{
+	"live":  "/livez",
+	"ready": "/readyz",
+	"deep":  "/health/deep",
+}
+
 func main() {
-	// This program simply makes an HTTP GET request to the URL provided
-	// as the first argument. It exits with status 1 if the request fails
-	// or if the status code is not 200 OK.
+	// This program makes an HTTP GET request and exits with status 1 if the
+	// request fails or the status code is not 200 OK. It is called either
+	// with a full URL:
+	//
+	//	health_check http://localhost:13133
+	//
+	// or with a probe type and a base URL, so the same binary serves
+	// liveness and readiness checks:
+	//
+	//	health_check live http://localhost:8081
+	//	health_check ready http://localhost:8081
+	var url string
+	switch len(os.Args) {
+	case 2:
+		url = os.Args[1]
+	case 3:
+		path, ok := probePaths[os.Args[1]]
+		if !ok {
+			log.Fatalf("Unknown probe type %q (want live, ready or deep)", os.Args[1])
+		}
+		url = strings.TrimSuffix(os.Args[2], "/") + path
+	default:
+		log.Fatalf("Usage: %s [live|ready|deep] <url>", os.Args[0])
+	}
+
 	client := http.Client{Timeout: 2 * time.Second}
-	resp, err := client.Get(os.Args[1])
+	resp, err := client.Get(url)
 	if err != nil {
 		log.Fatalf("Request failed: %v", err)
 	}
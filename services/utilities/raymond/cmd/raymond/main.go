@@ -0,0 +1,123 @@
+// Command raymond provisions the platform's messaging and storage resources
+// and serves health endpoints while doing so.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/bootstrap"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/health"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/server"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/telemetry"
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+	"github.com/oklog/run"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "path to the config file")
+	flag.Parse()
+
+	if err := runService(*configPath); err != nil {
+		slog.Error("raymond stopped", "error", err, "category", pkgerrors.CodeOf(err))
+		os.Exit(pkgerrors.ExitCode(err))
+	}
+}
+
+// runService wires the service together and runs it until a shutdown signal
+// or a fatal bootstrap failure. Errors wrap the sentinel of their category so
+// main can exit with the matching code.
+func runService(configPath string) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	provider, err := telemetry.NewProvider(context.Background(), &cfg.Telemetry, cfg.Security.TLSConfig())
+	if err != nil {
+		return fmt.Errorf("%w: %w", pkgerrors.ErrTelemetrySetup, err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			slog.Error("telemetry shutdown failed", "error", err)
+		}
+	}()
+	logger := provider.Logger()
+	slog.SetDefault(logger)
+
+	metrics, err := telemetry.NewMetrics(provider.Meter())
+	if err != nil {
+		return fmt.Errorf("%w: %w", pkgerrors.ErrTelemetrySetup, err)
+	}
+
+	orchestrator := bootstrap.NewOrchestrator(cfg, logger, provider.Tracer(), metrics)
+	handler := health.NewHandler(orchestrator.Checker(), logger)
+	handler.SetReadinessDelay(cfg.Health.ReadinessDelay)
+	handler.SetDeepHealthCacheTTL(cfg.Server.DeepHealthTTL)
+	handler.SetRequireCriticalDependencies(cfg.Health.ReadinessRequiresCriticalDeps)
+	handler.AddBlockingReasons(orchestrator.Status().BlockingPhases)
+	if cfg.Health.ReadyRequiresTelemetry {
+		handler.AddReadinessCheck(provider.Readiness)
+	}
+	orchestrator.OnComplete(func() { handler.SetReady(true) })
+
+	srv := server.NewServer(&cfg.Server, logger, metrics, handler, orchestrator.Status(), orchestrator.Breakers())
+	srv.SetMetricsHandler(cfg.Telemetry.PrometheusPath, provider.MetricsHandler())
+	srv.SetLogSampler(provider.LogSampler())
+
+	reloader := config.NewReloader(configPath, config.LoadOptions{}, cfg, logger)
+	reloader.OnReload(func(cfg *config.Config) { provider.SetLogLevel(cfg.Telemetry.LogLevel) })
+	reloader.OnReload(orchestrator.ApplyConfig)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	// Without a handler SIGHUP would terminate the process
+	go reloader.WatchSignals(ctx)
+
+	// The first actor to return stops the others: a signal or a fatal
+	// bootstrap error shuts the server down, a server failure stops bootstrap
+	var g run.Group
+	g.Add(func() error {
+		select {
+		case sig := <-signals:
+			logger.Info("shutdown signal received, send another to abort in-flight bootstrap work",
+				"signal", sig.String())
+		case <-ctx.Done():
+			return nil
+		}
+		// Phase retries normally get a grace period to finish; a second
+		// signal cancels them at once
+		go func() {
+			<-signals
+			logger.Warn("second shutdown signal received, aborting bootstrap")
+			orchestrator.Abort()
+		}()
+		return nil
+	}, func(error) {
+		cancel()
+	})
+	g.Add(func() error {
+		return orchestrator.Run(ctx)
+	}, func(error) {
+		cancel()
+	})
+	g.Add(srv.Start, func(error) {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Error("server shutdown failed", "error", err)
+		}
+	})
+	return g.Run()
+}
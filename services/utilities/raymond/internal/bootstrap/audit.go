@@ -0,0 +1,54 @@
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Audit actions recorded for provisioning changes.
+const (
+	AuditActionStreamCreate    = "nats.stream.create"
+	AuditActionConsumerCreate  = "nats.consumer.create"
+	AuditActionKVCreate        = "nats.kv.create"
+	AuditActionNamespaceCreate = "pulsar.namespace.create"
+	AuditActionTopicCreate     = "pulsar.topic.create"
+	AuditActionSchemaValidate  = "postgres.schema.validate"
+	AuditActionMigrationApply  = "postgres.migration.apply"
+)
+
+// AuditLogger records every infrastructure change made during bootstrap as a
+// structured entry under the "audit" group, tagged log.type=audit so it can
+// be routed separately from operational logs.
+type AuditLogger struct {
+	logger *slog.Logger
+	actor  string
+}
+
+// NewAuditLogger creates an audit logger writing to logger on behalf of actor,
+// normally the service name.
+func NewAuditLogger(logger *slog.Logger, actor string) *AuditLogger {
+	return &AuditLogger{
+		logger: logger.With("log.type", "audit"),
+		actor:  actor,
+	}
+}
+
+// Record writes an audit entry for action on resource. A nil err records a
+// success, anything else a failure with the error message.
+func (a *AuditLogger) Record(ctx context.Context, action, resource string, err error) {
+	result := "success"
+	level := slog.LevelInfo
+	attrs := []any{
+		"actor", a.actor,
+		"action", action,
+		"resource", resource,
+	}
+	if err != nil {
+		result = "failure"
+		level = slog.LevelWarn
+		attrs = append(attrs, "error", err.Error())
+	}
+	attrs = append(attrs, "result", result)
+
+	a.logger.Log(ctx, level, "audit", slog.Group("audit", attrs...))
+}
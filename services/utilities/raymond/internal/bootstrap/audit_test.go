@@ -0,0 +1,191 @@
+package bootstrap
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/clients"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+// auditEntry is the JSON form of an audit log record.
+type auditEntry struct {
+	Level   string `json:"level"`
+	Msg     string `json:"msg"`
+	LogType string `json:"log.type"`
+	Audit   struct {
+		Actor    string `json:"actor"`
+		Action   string `json:"action"`
+		Resource string `json:"resource"`
+		Result   string `json:"result"`
+		Error    string `json:"error"`
+	} `json:"audit"`
+}
+
+// decodeAuditEntry decodes the single audit entry written to logs.
+func decodeAuditEntry(t *testing.T, logs *bytes.Buffer) auditEntry {
+	t.Helper()
+	var entry auditEntry
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("decode audit entry %q: %v", logs.String(), err)
+	}
+	if entry.LogType != "audit" {
+		t.Errorf("log.type = %q, want %q", entry.LogType, "audit")
+	}
+	return entry
+}
+
+// serveJetStream starts a minimal NATS server with JetStream that answers
+// account info and stream creation requests, and returns its URL.
+func serveJetStream(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go serveJetStreamConn(conn)
+		}
+	}()
+	return "nats://" + lis.Addr().String()
+}
+
+// serveJetStreamConn speaks just enough of the NATS protocol for a client to
+// connect and make JetStream API requests.
+func serveJetStreamConn(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprint(conn, "INFO {\"server_id\":\"fake\",\"version\":\"2.10.0\",\"proto\":1,\"headers\":true,\"jetstream\":true,\"max_payload\":1048576}\r\n")
+
+	r := bufio.NewReader(conn)
+	sid := "1"
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "SUB":
+			sid = fields[len(fields)-1]
+		case "PUB", "HPUB":
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			if _, err := io.CopyN(io.Discard, r, int64(size)+2); err != nil {
+				return
+			}
+			subject, reply := fields[1], fields[2]
+			var resp string
+			switch {
+			case subject == "$JS.API.INFO":
+				resp = `{"type":"io.nats.jetstream.api.v1.account_info_response"}`
+			case strings.HasPrefix(subject, "$JS.API.STREAM.CREATE."):
+				name := strings.TrimPrefix(subject, "$JS.API.STREAM.CREATE.")
+				resp = `{"type":"io.nats.jetstream.api.v1.stream_create_response","config":{"name":"` + name +
+					`"},"created":"2026-01-01T00:00:00Z","state":{}}`
+			default:
+				continue
+			}
+			fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", reply, sid, len(resp), resp)
+		}
+	}
+}
+
+func TestCreateNATSStreamWritesAuditEntry(t *testing.T) {
+	cfg := testConfig(t, hangingListener(t))
+	cfg.Bootstrap.NATS.URL = serveJetStream(t)
+	client, err := clients.NewNATSClient(context.Background(), cfg.Bootstrap.NATS)
+	if err != nil {
+		t.Fatalf("NewNATSClient: %v", err)
+	}
+	defer client.Close()
+
+	o, _ := newTestOrchestrator(t, cfg)
+	var logs bytes.Buffer
+	o.audit = NewAuditLogger(slog.New(slog.NewJSONHandler(&logs, nil)), "raymond")
+
+	stream := config.StreamConfig{Name: "EVENTS", Subjects: []string{"events.>"}, Retention: "limits", Replicas: 1}
+	if err := o.createNATSStream(context.Background(), client, stream); err != nil {
+		t.Fatalf("createNATSStream: %v", err)
+	}
+
+	entry := decodeAuditEntry(t, &logs)
+	want := auditEntry{Level: "INFO", Msg: "audit", LogType: "audit"}
+	want.Audit.Actor = "raymond"
+	want.Audit.Action = AuditActionStreamCreate
+	want.Audit.Resource = "EVENTS"
+	want.Audit.Result = "success"
+	if entry != want {
+		t.Errorf("audit entry = %+v, want %+v", entry, want)
+	}
+}
+
+func TestCreatePulsarTopicWritesAuditEntry(t *testing.T) {
+	tests := []struct {
+		name        string
+		adminStatus int
+		wantLevel   string
+		wantResult  string
+	}{
+		{"created", http.StatusNoContent, "INFO", "success"},
+		{"forbidden", http.StatusForbidden, "WARN", "failure"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.adminStatus)
+			}))
+			defer admin.Close()
+
+			cfg := testConfig(t, hangingListener(t))
+			cfg.Bootstrap.Pulsar.AdminURL = admin.URL
+			client, err := clients.NewPulsarClient(context.Background(), cfg.Bootstrap.Pulsar)
+			if err != nil {
+				t.Fatalf("NewPulsarClient: %v", err)
+			}
+			defer client.Close()
+
+			o, _ := newTestOrchestrator(t, cfg)
+			var logs bytes.Buffer
+			o.audit = NewAuditLogger(slog.New(slog.NewJSONHandler(&logs, nil)), "raymond")
+
+			// A 403 is permanent, so this doesn't retry
+			o.createPulsarTopic(context.Background(), client, config.TopicConfig{Name: "events/agent-lifecycle"})
+
+			entry := decodeAuditEntry(t, &logs)
+			if entry.Level != tt.wantLevel {
+				t.Errorf("level = %q, want %q", entry.Level, tt.wantLevel)
+			}
+			got := entry.Audit
+			if got.Actor != "raymond" || got.Action != AuditActionTopicCreate ||
+				got.Resource != "events/agent-lifecycle" || got.Result != tt.wantResult {
+				t.Errorf("audit = %+v, want raymond %s events/agent-lifecycle %s", got, AuditActionTopicCreate, tt.wantResult)
+			}
+			if (got.Error != "") != (tt.wantResult == "failure") {
+				t.Errorf("audit error = %q, want one only on failure", got.Error)
+			}
+		})
+	}
+}
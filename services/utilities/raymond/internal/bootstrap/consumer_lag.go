@@ -0,0 +1,77 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/clients"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/supervisor"
+)
+
+// ConsumerInfoSource reports how many messages are pending for a consumer.
+type ConsumerInfoSource interface {
+	ConsumerPending(ctx context.Context, stream, consumer string) (uint64, error)
+}
+
+// monitorConsumerLag periodically records the pending count of the configured
+// JetStream consumers. The NATS connection is (re)established lazily so that
+// an unavailable broker at startup does not stop collection.
+func (o *Orchestrator) monitorConsumerLag(ctx context.Context, hb *supervisor.Heartbeat) {
+	lagCfg := o.cfg.Bootstrap.NATS.ConsumerLag
+	ticker := time.NewTicker(lagCfg.Interval)
+	defer ticker.Stop()
+
+	o.logger.Info("starting NATS consumer lag monitoring",
+		"consumers", len(lagCfg.Consumers),
+		"interval", lagCfg.Interval.String())
+
+	var client *clients.NATSClient
+	defer func() {
+		if client != nil {
+			client.Close()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			o.logger.Info("stopping NATS consumer lag monitoring")
+			return
+		case <-ticker.C:
+			if client == nil {
+				c, err := clients.NewNATSClient(ctx, o.cfg.Bootstrap.NATS, o.breakerObserver())
+				if err != nil {
+					o.logger.Warn("consumer lag: NATS unavailable", "error", err)
+				} else {
+					client = c
+				}
+			}
+			if client != nil {
+				o.collectConsumerLag(ctx, client, lagCfg.Consumers)
+			}
+			// Beat once the cycle is done, as monitorDependencies does
+			hb.Beat()
+		}
+	}
+}
+
+// collectConsumerLag records the pending count of each consumer from source.
+func (o *Orchestrator) collectConsumerLag(ctx context.Context, source ConsumerInfoSource, consumers []config.ConsumerRef) {
+	for _, ref := range consumers {
+		pending, err := source.ConsumerPending(ctx, ref.Stream, ref.Consumer)
+		if err != nil {
+			o.logger.Warn("failed to read consumer lag",
+				"stream", ref.Stream,
+				"consumer", ref.Consumer,
+				"error", err)
+			continue
+		}
+
+		o.metrics.RecordConsumerPending(ctx, ref.Stream, ref.Consumer, pending)
+		o.logger.Debug("consumer lag",
+			"stream", ref.Stream,
+			"consumer", ref.Consumer,
+			"pending", pending)
+	}
+}
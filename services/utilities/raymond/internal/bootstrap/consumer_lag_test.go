@@ -0,0 +1,50 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// fakeConsumerInfo reports fixed pending counts, keyed by "stream/consumer".
+// Consumers it doesn't know fail to be read.
+type fakeConsumerInfo map[string]uint64
+
+func (f fakeConsumerInfo) ConsumerPending(_ context.Context, stream, consumer string) (uint64, error) {
+	pending, ok := f[stream+"/"+consumer]
+	if !ok {
+		return 0, errors.New("consumer not found")
+	}
+	return pending, nil
+}
+
+func TestCollectConsumerLag(t *testing.T) {
+	o, reader := newTestOrchestrator(t, testConfig(t, hangingListener(t)))
+	source := fakeConsumerInfo{"EVENTS/billing": 42, "EVENTS/audit": 0}
+
+	o.collectConsumerLag(context.Background(), source, []config.ConsumerRef{
+		{Stream: "EVENTS", Consumer: "billing"},
+		{Stream: "EVENTS", Consumer: "audit"},
+		{Stream: "EVENTS", Consumer: "missing"},
+	})
+
+	got := make(map[string]int64)
+	for _, dp := range gaugePoints(t, reader, "raymond.nats.consumer_pending") {
+		stream, _ := dp.Attributes.Value(attribute.Key("stream"))
+		consumer, _ := dp.Attributes.Value(attribute.Key("consumer"))
+		got[stream.AsString()+"/"+consumer.AsString()] = dp.Value
+	}
+
+	want := map[string]int64{"EVENTS/billing": 42, "EVENTS/audit": 0}
+	if len(got) != len(want) {
+		t.Errorf("consumer_pending = %v, want %v", got, want)
+	}
+	for key, pending := range want {
+		if got[key] != pending {
+			t.Errorf("consumer_pending{%s} = %d, want %d", key, got[key], pending)
+		}
+	}
+}
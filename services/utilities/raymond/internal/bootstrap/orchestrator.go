@@ -5,27 +5,64 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/arc-framework/platform-spike/services/raymond/internal/clients"
 	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
 	"github.com/arc-framework/platform-spike/services/raymond/internal/health"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/supervisor"
 	"github.com/arc-framework/platform-spike/services/raymond/internal/telemetry"
 	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
 	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+	"github.com/sony/gobreaker"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
+// flapThreshold is the number of state transitions in a dependency's recent
+// probe history at which it is reported as flapping.
+const flapThreshold = 3
+
+// defaultDownSummaryInterval is how often a dependency that stays unhealthy
+// is reported again after the initial warning. Cycles in between log at debug.
+const defaultDownSummaryInterval = 5 * time.Minute
+
+// defaultAttemptTimeout bounds a single attempt of a bootstrap phase,
+// including the per-resource retries inside it.
+const defaultAttemptTimeout = 30 * time.Second
+
 // Orchestrator manages the platform bootstrap process.
 type Orchestrator struct {
+	runID   string
 	cfg     *config.Config
 	logger  *slog.Logger
 	tracer  trace.Tracer
 	metrics *telemetry.Metrics
 	checker *health.Checker
+	status  *Status
+	audit   *AuditLogger
+	clients *clients.Lifecycle
+	// breakers reports the circuit breaker of the latest client per dependency
+	breakers *clients.BreakerRegistry
+	// monitorInterval is how often monitorDependencies re-checks dependencies
+	monitorInterval time.Duration
+	// downSummaryInterval is how often a dependency that stays down is
+	// reported again
+	downSummaryInterval time.Duration
+	// attemptTimeout bounds each attempt of a phase
+	attemptTimeout time.Duration
+
+	// onComplete is called once every critical phase has succeeded
+	onComplete []func()
+
+	// hardStop parents every retry context so Abort can cancel them at once
+	hardStop context.Context
+	abort    context.CancelFunc
 }
 
 // NewOrchestrator creates a new bootstrap orchestrator.
@@ -35,43 +72,203 @@ func NewOrchestrator(
 	tracer trace.Tracer,
 	metrics *telemetry.Metrics,
 ) *Orchestrator {
-	checker := health.NewChecker(cfg.Bootstrap.Dependencies, logger, 5*time.Second)
+	runID := uuid.NewString()
+	logger = logger.With("bootstrap.run_id", runID)
+	checker := health.NewChecker(cfg.Bootstrap.Dependencies, logger, cfg.Security.TLSConfig(), cfg.Health.CheckTimeout)
+	checker.SetMetrics(metrics)
+	checker.SetTracer(tracer)
+	checker.SetWarmup(cfg.Health.Warmup)
+	checker.SetFailOnCriticalTimeout(cfg.Bootstrap.FailOnCriticalTimeout)
+	checker.SetProbeBatching(cfg.Health.ProbeBatchSize, cfg.Health.ProbeBatchInterval)
+	hardStop, abort := context.WithCancel(context.Background())
 	return &Orchestrator{
-		cfg:     cfg,
-		logger:  logger,
-		tracer:  tracer,
-		metrics: metrics,
-		checker: checker,
+		runID:               runID,
+		cfg:                 cfg,
+		logger:              logger,
+		tracer:              tracer,
+		metrics:             metrics,
+		checker:             checker,
+		status:              NewStatus(runID),
+		audit:               NewAuditLogger(logger, cfg.Telemetry.ServiceName),
+		clients:             clients.NewLifecycle(),
+		breakers:            clients.NewBreakerRegistry(),
+		monitorInterval:     config.DependencyMonitorInterval,
+		downSummaryInterval: defaultDownSummaryInterval,
+		attemptTimeout:      defaultAttemptTimeout,
+		hardStop:            hardStop,
+		abort:               abort,
+	}
+}
+
+// Abort cancels all in-flight phase retries immediately, skipping the grace
+// period normally given on shutdown. It is meant for a second interrupt.
+func (o *Orchestrator) Abort() {
+	o.abort()
+}
+
+// RunID returns the ID shared by all telemetry of this bootstrap run.
+func (o *Orchestrator) RunID() string {
+	return o.runID
+}
+
+// startSpan starts a bootstrap span tagged with the run ID. Metrics recorded
+// under the span's context pick it up as an exemplar.
+func (o *Orchestrator) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return o.tracer.Start(ctx, name,
+		trace.WithAttributes(attribute.String("bootstrap.run_id", o.runID)))
+}
+
+// Checker returns the health checker probing the bootstrap dependencies, for
+// serving their health.
+func (o *Orchestrator) Checker() *health.Checker {
+	return o.checker
+}
+
+// OnComplete registers fn to be called when every critical phase has
+// succeeded, e.g. to mark the service ready. It must be called before Run.
+func (o *Orchestrator) OnComplete(fn func()) {
+	o.onComplete = append(o.onComplete, fn)
+}
+
+// Status returns the tracker reporting bootstrap phase progress.
+func (o *Orchestrator) Status() *Status {
+	return o.status
+}
+
+// ApplyConfig applies the settings of a reloaded config that take effect
+// while running: the dependency list, including probe timeouts, is swapped
+// into the health checker along with the default probe timeout. With consul
+// discovery the checker keeps the discovered dependencies.
+func (o *Orchestrator) ApplyConfig(cfg *config.Config) {
+	if cfg.Health.Discovery.Source != "consul" {
+		o.checker.SetDependencies(cfg.Bootstrap.Dependencies)
 	}
+	o.checker.SetTimeout(cfg.Health.CheckTimeout)
+}
+
+// Breakers returns the registry of client circuit breaker states.
+func (o *Orchestrator) Breakers() *clients.BreakerRegistry {
+	return o.breakers
+}
+
+// breakerObserver reports client circuit breaker transitions as metrics and
+// logs, so a tripped breaker is visible before downstream failures pile up.
+func (o *Orchestrator) breakerObserver() clients.Option {
+	return clients.WithBreakerStateChange(func(name string, from, to gobreaker.State) {
+		o.logger.Warn("circuit breaker state changed",
+			"breaker", name,
+			"from", from.String(),
+			"to", to.String())
+		o.metrics.RecordBreakerStateChange(context.Background(), name, from.String(), to.String(), int64(to))
+	})
 }
 
 // Run executes the complete bootstrap workflow asynchronously.
 // The service will start even if dependencies are not ready.
 // Dependencies are checked in the background with automatic retries.
+// With fail_on_critical_unreachable set, Run returns an error wrapping
+// ErrDependencyUnhealthy if a critical dependency is not healthy once within
+// bootstrap.timeout, so the caller can exit non-zero. With
+// fail_on_critical_timeout set, Run instead waits up to bootstrap.timeout
+// for the critical dependencies before provisioning and returns such an
+// error if one is still unhealthy.
 func (o *Orchestrator) Run(ctx context.Context) error {
-	ctx, span := o.tracer.Start(ctx, "bootstrap.run")
+	ctx, span := o.startSpan(ctx, "bootstrap.run")
 	defer span.End()
 
 	startTime := time.Now()
 	o.logger.Info("starting platform bootstrap (async mode)")
 
-	// Start async dependency monitoring in background
-	go o.monitorDependencies(ctx)
+	// Start async dependency monitoring in background, restarting any loop
+	// that stops making progress
+	workers := supervisor.New(o.logger, o.cfg.Bootstrap.StallThreshold)
+	workers.Go(ctx, "monitor_dependencies", o.monitorDependencies)
+
+	if o.cfg.Bootstrap.NATS.ConsumerLag.Enabled {
+		workers.Go(ctx, "monitor_consumer_lag", o.monitorConsumerLag)
+	}
+	go workers.Run(ctx)
+
+	// Keep the probe list in sync with the service registry, if one is used
+	if discovery := o.cfg.Health.Discovery; discovery.Source == "consul" {
+		source := health.NewConsulSource(discovery.ConsulAddress, discovery.ConsulTag)
+		go o.checker.WatchSource(ctx, source, discovery.Interval)
+	}
 
 	// Phase 1: Quick dependency check (non-blocking)
 	o.checkDependenciesAsync(ctx)
 
-	// Phase 2: Initialize NATS JetStream (with retry, non-blocking)
-	go o.initializeWithRetry(ctx, "initialize_nats", o.initializeNATS)
+	// Optionally hold provisioning until the critical dependencies are up,
+	// and fail the run if one doesn't come up in time
+	if o.cfg.Bootstrap.FailOnCriticalTimeout {
+		waitCtx, cancel := context.WithTimeout(ctx, o.cfg.Bootstrap.Timeout)
+		err := o.checker.WaitForDependencies(waitCtx)
+		cancel()
+		if err != nil {
+			return o.failUnreachable(span, err)
+		}
+	}
 
-	// Phase 3: Initialize Pulsar (with retry, non-blocking)
-	go o.initializeWithRetry(ctx, "initialize_pulsar", o.initializePulsar)
+	// Optionally give up on the whole run if a critical dependency never
+	// comes up, so the process exits and the scheduler notices
+	var unreachable chan error
+	if o.cfg.Bootstrap.FailOnCriticalUnreachable {
+		unreachable = make(chan error, 1)
+		go func() {
+			if err := o.watchCriticalDependencies(ctx); err != nil {
+				unreachable <- err
+			}
+		}()
+	}
 
-	// Phase 4: Validate Database (optional, non-blocking)
-	go o.initializeWithRetry(ctx, "validate_database", o.validateDatabase)
+	// Bound the provisioning phases by the total duration budget, if any.
+	// Monitoring keeps using the parent context and is not affected.
+	phaseCtx := ctx
+	if maxTotal := o.cfg.Bootstrap.MaxTotalDuration; maxTotal > 0 {
+		var cancel context.CancelFunc
+		phaseCtx, cancel = context.WithTimeoutCause(ctx, maxTotal, pkgerrors.ErrBootstrapDeadline)
+		defer cancel()
+	}
 
+	type phase struct {
+		name     string
+		critical bool
+		fn       func(context.Context) error
+	}
+	bootstrapPhases := []phase{
+		// Phase 2: Initialize NATS JetStream (with retry, non-blocking)
+		{"initialize_nats", true, o.initializeNATS},
+		// Phase 3: Initialize Pulsar (with retry, non-blocking)
+		{"initialize_pulsar", true, o.initializePulsar},
+		// Phase 4: Validate Database (optional, non-blocking)
+		{"validate_database", false, o.validateDatabase},
+	}
+	if o.cfg.Bootstrap.Postgres.Migrations.Enabled {
+		bootstrapPhases = append(bootstrapPhases, phase{"migrate_database", true, o.migrateDatabase})
+	}
 	// Phase 5: Cache Warming (optional, non-blocking)
-	go o.initializeWithRetry(ctx, "warm_cache", o.warmCache)
+	bootstrapPhases = append(bootstrapPhases, phase{"warm_cache", false, o.warmCache})
+
+	// Register every phase before starting any, so a fast critical phase
+	// can't complete bootstrap while others are not registered yet
+	for _, p := range bootstrapPhases {
+		o.status.Register(p.name, p.critical)
+	}
+
+	var phases sync.WaitGroup
+	for _, p := range bootstrapPhases {
+		phases.Add(1)
+		go func() {
+			defer phases.Done()
+			o.initializeWithRetry(phaseCtx, p.name, p.fn)
+		}()
+	}
+
+	phasesDone := make(chan struct{})
+	go func() {
+		phases.Wait()
+		close(phasesDone)
+	}()
 
 	duration := time.Since(startTime).Seconds()
 	o.metrics.RecordBootstrapDuration(ctx, duration)
@@ -81,19 +278,118 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 	o.logger.Info("platform bootstrap initiated (running in background)",
 		"duration_seconds", duration)
 
+	// Abort if the phases overrun the total duration budget
+	select {
+	case <-phasesDone:
+	case err := <-unreachable:
+		return o.failUnreachable(span, err)
+	case <-phaseCtx.Done():
+		if errors.Is(context.Cause(phaseCtx), pkgerrors.ErrBootstrapDeadline) {
+			err := fmt.Errorf("%w: exceeded %s", pkgerrors.ErrBootstrapDeadline, o.cfg.Bootstrap.MaxTotalDuration)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "bootstrap deadline exceeded")
+			o.logger.Error("bootstrap aborted, in-flight phases canceled",
+				"max_total_duration", o.cfg.Bootstrap.MaxTotalDuration.String())
+			o.closeClients()
+			return err
+		}
+	}
+
 	// Wait for shutdown signal
-	<-ctx.Done()
+	select {
+	case <-ctx.Done():
+	case err := <-unreachable:
+		return o.failUnreachable(span, err)
+	}
 	o.logger.Info("bootstrap orchestrator received shutdown signal")
 
-	// Give background tasks a moment to complete gracefully
-	time.Sleep(2 * time.Second)
+	// Let in-flight phases finish, then close clients newest first
+	o.closeClients()
 
 	return nil
 }
 
+// watchCriticalDependencies follows the background monitor's results until
+// every critical dependency has been healthy at least once. It doesn't probe
+// on its own, so dependencies are seen at the monitor's interval. It returns
+// an ErrDependencyUnhealthy error naming the ones that never were healthy if
+// bootstrap.timeout passes first, and nil if ctx is canceled.
+func (o *Orchestrator) watchCriticalDependencies(ctx context.Context) error {
+	timeout := o.cfg.Bootstrap.Timeout
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	seenHealthy := make(map[string]bool)
+	var lastChecked time.Time
+	observe := func() {
+		results, at, ok := o.checker.Monitored().Load()
+		if !ok || !at.After(lastChecked) {
+			return
+		}
+		lastChecked = at
+		for name, result := range results {
+			if result.OK {
+				seenHealthy[name] = true
+			}
+		}
+	}
+	pending := func() []string {
+		var names []string
+		for _, dep := range o.checker.Dependencies() {
+			if dep.Critical && !seenHealthy[dep.Name] {
+				names = append(names, dep.Name)
+			}
+		}
+		return names
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-deadline.C:
+			observe()
+			names := pending()
+			if len(names) == 0 {
+				return nil
+			}
+			return fmt.Errorf("%w: %s not reachable within %s",
+				pkgerrors.ErrDependencyUnhealthy, strings.Join(names, ", "), timeout)
+		case <-ticker.C:
+			observe()
+			if len(pending()) == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// failUnreachable ends the run because a critical dependency never became
+// reachable.
+func (o *Orchestrator) failUnreachable(span trace.Span, err error) error {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, "critical dependency unreachable")
+	o.logger.Error("critical dependency unreachable, aborting bootstrap", "error", err)
+	o.closeClients()
+	return err
+}
+
+// closeClients closes the clients created during bootstrap once in-flight
+// phase work has finished, bounded by the server shutdown timeout.
+func (o *Orchestrator) closeClients() {
+	ctx, cancel := context.WithTimeout(context.Background(), o.cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := o.clients.Shutdown(ctx); err != nil {
+		o.logger.Warn("error closing bootstrap clients", "error", err)
+	}
+}
+
 // runPhase executes a bootstrap phase with timing and error handling.
 func (o *Orchestrator) runPhase(ctx context.Context, phaseName string, fn func(context.Context) error) error {
-	ctx, span := o.tracer.Start(ctx, fmt.Sprintf("bootstrap.%s", phaseName))
+	ctx, span := o.startSpan(ctx, fmt.Sprintf("bootstrap.%s", phaseName))
 	defer span.End()
 
 	startTime := time.Now()
@@ -134,36 +430,74 @@ func (o *Orchestrator) waitForDependencies(ctx context.Context) error {
 	return o.checker.WaitForDependencies(ctx)
 }
 
-// initializeNATS creates JetStream streams concurrently.
+// initializeNATS creates JetStream streams and KV buckets concurrently, then
+// the consumers on those streams.
 func (o *Orchestrator) initializeNATS(ctx context.Context) error {
-	if len(o.cfg.Bootstrap.NATS.Streams) == 0 {
-		o.logger.Info("no NATS streams configured, skipping")
+	natsCfg := o.cfg.Bootstrap.NATS
+	if len(natsCfg.Streams) == 0 && len(natsCfg.Consumers) == 0 && len(natsCfg.KV) == 0 {
+		o.logger.Info("no NATS streams, consumers or KV buckets configured, skipping")
 		return nil
 	}
 
-	client, err := clients.NewNATSClient(ctx, o.cfg.Bootstrap.NATS)
+	client, err := clients.NewNATSClient(ctx, o.cfg.Bootstrap.NATS, o.breakerObserver())
 	if err != nil {
 		return fmt.Errorf("create NATS client: %w", err)
 	}
-	defer client.Close()
+	o.breakers.Register("nats", client)
+	unobserve, err := o.metrics.ObserveNATSConn(client)
+	if err != nil {
+		client.Close()
+		return err
+	}
+	// Kept open until shutdown unless provisioning fails
+	release := o.clients.Register("nats", func() error {
+		client.Close()
+		return unobserve()
+	})
 
-	// Create streams concurrently
+	// Create streams and KV buckets concurrently
 	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(5) // Limit concurrent operations
 
-	for _, streamCfg := range o.cfg.Bootstrap.NATS.Streams {
+	for _, streamCfg := range natsCfg.Streams {
 		streamCfg := streamCfg
 		g.Go(func() error {
 			return o.createNATSStream(gctx, client, streamCfg)
 		})
 	}
+	for _, kvCfg := range natsCfg.KV {
+		kvCfg := kvCfg
+		g.Go(func() error {
+			return o.createNATSKV(gctx, client, kvCfg)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		release()
+		return err
+	}
+
+	// Consumers need their stream, so they go once all streams exist
+	g, gctx = errgroup.WithContext(ctx)
+	g.SetLimit(5)
 
-	return g.Wait()
+	for _, consumerCfg := range natsCfg.Consumers {
+		consumerCfg := consumerCfg
+		g.Go(func() error {
+			return o.createNATSConsumer(gctx, client, consumerCfg)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		release()
+		return err
+	}
+	return nil
 }
 
 // createNATSStream creates a single NATS stream with retry.
 func (o *Orchestrator) createNATSStream(ctx context.Context, client *clients.NATSClient, cfg config.StreamConfig) error {
-	ctx, span := o.tracer.Start(ctx, "bootstrap.create_nats_stream")
+	ctx, span := o.startSpan(ctx, "bootstrap.create_nats_stream")
 	defer span.End()
 
 	span.SetAttributes(
@@ -174,18 +508,18 @@ func (o *Orchestrator) createNATSStream(ctx context.Context, client *clients.NAT
 	o.logger.Info("creating NATS stream", "name", cfg.Name)
 
 	operation := func() error {
-		return client.CreateStream(ctx, cfg)
+		err := client.CreateStream(ctx, cfg)
+		if pkgerrors.IsPermanent(err) {
+			return backoff.Permanent(err)
+		}
+		return err
 	}
 
-	b := backoff.WithContext(
-		backoff.WithMaxRetries(
-			backoff.NewExponentialBackOff(),
-			uint64(o.cfg.Bootstrap.RetryAttempts),
-		),
-		ctx,
-	)
+	b := backoff.WithContext(o.newBackOff(0), ctx)
 
-	if err := backoff.Retry(operation, b); err != nil {
+	err := backoff.Retry(operation, b)
+	o.audit.Record(ctx, AuditActionStreamCreate, cfg.Name, err)
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to create stream")
 		return fmt.Errorf("create stream %s: %w", cfg.Name, err)
@@ -195,23 +529,112 @@ func (o *Orchestrator) createNATSStream(ctx context.Context, client *clients.NAT
 	return nil
 }
 
-// initializePulsar creates Pulsar topics concurrently.
+// createNATSConsumer creates a single JetStream consumer with retry.
+func (o *Orchestrator) createNATSConsumer(ctx context.Context, client *clients.NATSClient, cfg config.ConsumerConfig) error {
+	ctx, span := o.startSpan(ctx, "bootstrap.create_nats_consumer")
+	defer span.End()
+
+	resource := cfg.Stream + "/" + cfg.Name
+	span.SetAttributes(
+		attribute.String("consumer.stream", cfg.Stream),
+		attribute.String("consumer.name", cfg.Name),
+	)
+
+	o.logger.Info("creating NATS consumer", "name", resource)
+
+	operation := func() error {
+		err := client.CreateConsumer(ctx, cfg)
+		if pkgerrors.IsPermanent(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	b := backoff.WithContext(o.newBackOff(0), ctx)
+
+	err := backoff.Retry(operation, b)
+	o.audit.Record(ctx, AuditActionConsumerCreate, resource, err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to create consumer")
+		return fmt.Errorf("create consumer %s: %w", resource, err)
+	}
+
+	o.logger.Info("NATS consumer created", "name", resource)
+	return nil
+}
+
+// createNATSKV creates a single JetStream KV bucket with retry.
+func (o *Orchestrator) createNATSKV(ctx context.Context, client *clients.NATSClient, cfg config.KVConfig) error {
+	ctx, span := o.startSpan(ctx, "bootstrap.create_nats_kv")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("kv.bucket", cfg.Bucket),
+		attribute.String("kv.ttl", cfg.TTL.String()),
+	)
+
+	o.logger.Info("creating NATS KV bucket", "bucket", cfg.Bucket)
+
+	operation := func() error {
+		err := client.CreateKV(ctx, cfg)
+		if pkgerrors.IsPermanent(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	b := backoff.WithContext(o.newBackOff(0), ctx)
+
+	err := backoff.Retry(operation, b)
+	o.audit.Record(ctx, AuditActionKVCreate, cfg.Bucket, err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to create kv bucket")
+		return fmt.Errorf("create kv bucket %s: %w", cfg.Bucket, err)
+	}
+
+	o.logger.Info("NATS KV bucket created", "bucket", cfg.Bucket)
+	return nil
+}
+
+// initializePulsar creates the configured Pulsar namespaces and then the
+// topics, each concurrently. Topics are only attempted once every namespace
+// exists.
 func (o *Orchestrator) initializePulsar(ctx context.Context) error {
-	if len(o.cfg.Bootstrap.Pulsar.Topics) == 0 {
-		o.logger.Info("no Pulsar topics configured, skipping")
+	if len(o.cfg.Bootstrap.Pulsar.Namespaces) == 0 && len(o.cfg.Bootstrap.Pulsar.Topics) == 0 {
+		o.logger.Info("no Pulsar namespaces or topics configured, skipping")
 		return nil
 	}
 
-	client, err := clients.NewPulsarClient(ctx, o.cfg.Bootstrap.Pulsar)
+	client, err := clients.NewPulsarClient(ctx, o.cfg.Bootstrap.Pulsar, o.breakerObserver())
 	if err != nil {
 		return fmt.Errorf("create Pulsar client: %w", err)
 	}
-	defer client.Close()
+	o.breakers.Register("pulsar", client)
+	// Kept open until shutdown unless provisioning fails
+	release := o.clients.Register("pulsar", client.Close)
 
-	// Create topics concurrently
+	// Create namespaces concurrently
 	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(5)
 
+	for _, namespace := range o.cfg.Bootstrap.Pulsar.Namespaces {
+		namespace := namespace
+		g.Go(func() error {
+			return o.createPulsarNamespace(gctx, client, namespace)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		release()
+		return err
+	}
+
+	// Create topics concurrently
+	g, gctx = errgroup.WithContext(ctx)
+	g.SetLimit(5)
+
 	for _, topicCfg := range o.cfg.Bootstrap.Pulsar.Topics {
 		topicCfg := topicCfg
 		g.Go(func() error {
@@ -219,12 +642,48 @@ func (o *Orchestrator) initializePulsar(ctx context.Context) error {
 		})
 	}
 
-	return g.Wait()
+	if err := g.Wait(); err != nil {
+		release()
+		return err
+	}
+	return nil
+}
+
+// createPulsarNamespace creates a single Pulsar namespace with retry.
+func (o *Orchestrator) createPulsarNamespace(ctx context.Context, client *clients.PulsarClient, namespace string) error {
+	ctx, span := o.startSpan(ctx, "bootstrap.create_pulsar_namespace")
+	defer span.End()
+
+	resource := o.cfg.Bootstrap.Pulsar.Tenant + "/" + namespace
+	span.SetAttributes(attribute.String("namespace.name", resource))
+
+	o.logger.Info("creating Pulsar namespace", "name", resource)
+
+	operation := func() error {
+		err := client.CreateNamespace(ctx, namespace)
+		if pkgerrors.IsPermanent(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	b := backoff.WithContext(o.newBackOff(0), ctx)
+
+	err := backoff.Retry(operation, b)
+	o.audit.Record(ctx, AuditActionNamespaceCreate, resource, err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to create namespace")
+		return fmt.Errorf("create namespace %s: %w", resource, err)
+	}
+
+	o.logger.Info("Pulsar namespace created", "name", resource)
+	return nil
 }
 
 // createPulsarTopic creates a single Pulsar topic with retry.
 func (o *Orchestrator) createPulsarTopic(ctx context.Context, client *clients.PulsarClient, cfg config.TopicConfig) error {
-	ctx, span := o.tracer.Start(ctx, "bootstrap.create_pulsar_topic")
+	ctx, span := o.startSpan(ctx, "bootstrap.create_pulsar_topic")
 	defer span.End()
 
 	span.SetAttributes(
@@ -235,18 +694,18 @@ func (o *Orchestrator) createPulsarTopic(ctx context.Context, client *clients.Pu
 	o.logger.Info("creating Pulsar topic", "name", cfg.Name)
 
 	operation := func() error {
-		return client.CreateTopic(ctx, cfg.Name, cfg.Partitions)
+		err := client.CreateTopic(ctx, cfg.Name, cfg.Partitions)
+		if pkgerrors.IsPermanent(err) {
+			return backoff.Permanent(err)
+		}
+		return err
 	}
 
-	b := backoff.WithContext(
-		backoff.WithMaxRetries(
-			backoff.NewExponentialBackOff(),
-			uint64(o.cfg.Bootstrap.RetryAttempts),
-		),
-		ctx,
-	)
+	b := backoff.WithContext(o.newBackOff(0), ctx)
 
-	if err := backoff.Retry(operation, b); err != nil {
+	err := backoff.Retry(operation, b)
+	o.audit.Record(ctx, AuditActionTopicCreate, cfg.Name, err)
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to create topic")
 		return fmt.Errorf("create topic %s: %w", cfg.Name, err)
@@ -258,23 +717,78 @@ func (o *Orchestrator) createPulsarTopic(ctx context.Context, client *clients.Pu
 
 // validateDatabase validates database schema existence.
 func (o *Orchestrator) validateDatabase(ctx context.Context) error {
-	client, err := clients.NewPostgresClient(ctx, o.cfg.Bootstrap.Postgres)
+	client, err := clients.NewPostgresClient(ctx, o.cfg.Bootstrap.Postgres, o.cfg.Security.TLSConfig(), o.breakerObserver())
 	if err != nil {
 		return fmt.Errorf("create postgres client: %w", err)
 	}
-	defer client.Close()
+	o.breakers.Register("postgres", client)
+	defer o.clients.Register("postgres", func() error {
+		client.Close()
+		return nil
+	})()
 
 	o.logger.Info("validating database schema")
-	return client.ValidateSchema(ctx, "public")
+	err = client.ValidateSchema(ctx, "public")
+	o.audit.Record(ctx, AuditActionSchemaValidate, "public", err)
+	return err
+}
+
+// migrateDatabase applies pending SQL migrations, or only logs them in dry
+// run mode.
+func (o *Orchestrator) migrateDatabase(ctx context.Context) error {
+	migrationsCfg := o.cfg.Bootstrap.Postgres.Migrations
+	migrations, err := clients.LoadMigrations(migrationsCfg.Dir)
+	if err != nil {
+		return err
+	}
+
+	client, err := clients.NewPostgresClient(ctx, o.cfg.Bootstrap.Postgres, o.cfg.Security.TLSConfig(), o.breakerObserver())
+	if err != nil {
+		return fmt.Errorf("create postgres client: %w", err)
+	}
+	o.breakers.Register("postgres-migrations", client)
+	defer o.clients.Register("postgres-migrations", func() error {
+		client.Close()
+		return nil
+	})()
+
+	o.logger.Info("applying database migrations",
+		"dir", migrationsCfg.Dir,
+		"available", len(migrations),
+		"dry_run", migrationsCfg.DryRun)
+
+	pending, err := client.Migrate(ctx, migrations, migrationsCfg.DryRun)
+	if migrationsCfg.DryRun {
+		for _, version := range pending {
+			o.logger.Info("pending migration (dry run, not applied)", "version", version)
+		}
+		return err
+	}
+
+	for i, version := range pending {
+		// Only the last pending version can have failed
+		var applyErr error
+		if i == len(pending)-1 {
+			applyErr = err
+		}
+		o.audit.Record(ctx, AuditActionMigrationApply, version, applyErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	o.logger.Info("database migrations complete", "applied", len(pending))
+	return nil
 }
 
 // warmCache performs optional cache warming operations.
 func (o *Orchestrator) warmCache(ctx context.Context) error {
-	client, err := clients.NewRedisClient(ctx, o.cfg.Bootstrap.Redis)
+	client, err := clients.NewRedisClient(ctx, o.cfg.Bootstrap.Redis, o.cfg.Security.TLSConfig(), o.breakerObserver())
 	if err != nil {
 		return fmt.Errorf("create redis client: %w", err)
 	}
-	defer client.Close()
+	o.breakers.Register("redis", client)
+	defer o.clients.Register("redis", client.Close)()
 
 	o.logger.Info("warming cache")
 	return client.Ping(ctx)
@@ -283,6 +797,7 @@ func (o *Orchestrator) warmCache(ctx context.Context) error {
 // checkDependenciesAsync performs a quick non-blocking check of dependencies.
 func (o *Orchestrator) checkDependenciesAsync(ctx context.Context) {
 	results := o.checker.RunAll(ctx)
+	o.checker.Monitored().Store(results)
 
 	for name, result := range results {
 		if result.OK {
@@ -298,76 +813,156 @@ func (o *Orchestrator) checkDependenciesAsync(ctx context.Context) {
 }
 
 // monitorDependencies continuously monitors dependency health in the background.
-func (o *Orchestrator) monitorDependencies(ctx context.Context) {
-	ticker := time.NewTicker(30 * time.Second)
+func (o *Orchestrator) monitorDependencies(ctx context.Context, hb *supervisor.Heartbeat) {
+	ticker := time.NewTicker(o.monitorInterval)
 	defer ticker.Stop()
 
 	o.logger.Info("starting background dependency monitoring")
 
+	// When each currently unhealthy dependency was first seen failing, and
+	// when it was last reported at warning level
+	unhealthySince := make(map[string]time.Time)
+	lastReported := make(map[string]time.Time)
+
 	for {
 		select {
 		case <-ctx.Done():
 			o.logger.Info("stopping dependency monitoring")
 			return
 		case <-ticker.C:
-			results := o.checker.RunAll(ctx)
+			cycleCtx, span := o.startSpan(ctx, "bootstrap.monitor_dependencies")
+			results := o.checker.RunAll(cycleCtx)
+			span.End()
+			o.checker.Monitored().Store(results)
 
 			healthyCount := 0
 			totalCount := len(results)
+			var flapping []string
 
 			for name, result := range results {
+				flaps := o.checker.FlapCount(name)
+				if flaps >= flapThreshold {
+					flapping = append(flapping, name)
+				}
+
 				if result.OK {
 					healthyCount++
+					if since, ok := unhealthySince[name]; ok {
+						outage := time.Since(since)
+						delete(unhealthySince, name)
+						delete(lastReported, name)
+						o.metrics.RecordOutage(ctx, name, outage.Seconds())
+						o.logger.Info("dependency recovered",
+							"service", name,
+							"outage_seconds", outage.Seconds())
+					}
 					o.logger.Debug("dependency health check",
 						"service", name,
 						"status", "healthy",
-						"latency_ms", result.LatencyMS)
+						"latency_ms", result.LatencyMS,
+						"flaps", flaps)
 				} else {
-					o.logger.Warn("dependency unhealthy",
-						"service", name,
-						"error", result.Error)
+					now := time.Now()
+					since, ok := unhealthySince[name]
+					switch {
+					case !ok:
+						unhealthySince[name] = now
+						lastReported[name] = now
+						o.logger.Warn("dependency unhealthy",
+							"service", name,
+							"error", result.Error,
+							"flaps", flaps)
+					case now.Sub(lastReported[name]) >= o.downSummaryInterval:
+						lastReported[name] = now
+						o.logger.Warn("dependency still unhealthy",
+							"service", name,
+							"down_for", now.Sub(since).Round(time.Second).String(),
+							"error", result.Error,
+							"flaps", flaps)
+					default:
+						o.logger.Debug("dependency health check",
+							"service", name,
+							"status", "unhealthy",
+							"error", result.Error,
+							"flaps", flaps)
+					}
 				}
 			}
 
 			o.logger.Info("dependency health summary",
 				"healthy", healthyCount,
-				"total", totalCount)
+				"total", totalCount,
+				"flapping", flapping)
+			hb.Beat()
 		}
 	}
 }
 
+// newBackOff returns the retry interval shared by every bootstrap retry loop:
+// exponential from retry_backoff, capped at 30s between attempts, giving up
+// once maxElapsed has passed (0 means no time limit). It doesn't cap the
+// number of attempts; retry_attempts applies to whole phases only, and
+// per-resource retries are bounded by the phase attempt's timeout, after
+// which the phase is retried.
+func (o *Orchestrator) newBackOff(maxElapsed time.Duration) backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = o.cfg.Bootstrap.RetryBackoff
+	b.MaxInterval = 30 * time.Second
+	b.MaxElapsedTime = maxElapsed
+	return b
+}
+
 // initializeWithRetry runs an initialization function with exponential backoff retry.
 // Uses a timeout-based context instead of the parent context to allow retries to complete.
 func (o *Orchestrator) initializeWithRetry(ctx context.Context, phaseName string, fn func(context.Context) error) {
 	o.logger.Info("starting async initialization", "phase", phaseName)
 
 	// Create a new context with timeout instead of using parent context
-	// This prevents premature cancellation during service shutdown
-	retryCtx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	// This prevents premature cancellation during service shutdown; only
+	// Abort cancels it right away
+	retryCtx, cancel := context.WithTimeout(o.hardStop, 10*time.Minute)
 	defer cancel()
 
+	// The detached context doesn't carry the bootstrap.run span, so attach it
+	// explicitly to keep the phase in the same trace
+	retryCtx, span := o.startSpan(trace.ContextWithSpan(retryCtx, trace.SpanFromContext(ctx)), "bootstrap."+phaseName)
+	defer span.End()
+	span.SetAttributes(attribute.String("phase", phaseName))
+
 	// But still respect the parent context cancellation
 	go func() {
 		select {
 		case <-ctx.Done():
+			// Total bootstrap budget exhausted - abort immediately
+			if errors.Is(context.Cause(ctx), pkgerrors.ErrBootstrapDeadline) {
+				cancel()
+				return
+			}
 			// Parent context canceled - give current operation 30s to finish gracefully
-			time.Sleep(30 * time.Second)
-			cancel()
+			select {
+			case <-time.After(30 * time.Second):
+				cancel()
+			case <-retryCtx.Done():
+			}
 		case <-retryCtx.Done():
 			// Retry context timed out naturally
 		}
 	}()
 
-	backoffStrategy := backoff.NewExponentialBackOff()
-	backoffStrategy.InitialInterval = 2 * time.Second
-	backoffStrategy.MaxInterval = 30 * time.Second
-	backoffStrategy.MaxElapsedTime = 5 * time.Minute // Retry for up to 5 minutes
+	// Retry up to retry_attempts times, and for no longer than 5 minutes
+	backoffStrategy := backoff.WithMaxRetries(o.newBackOff(5*time.Minute), uint64(o.cfg.Bootstrap.RetryAttempts))
 
 	operation := func() error {
 		// Use a fresh context for each attempt
-		phaseCtx, phaseCancel := context.WithTimeout(retryCtx, 30*time.Second)
+		phaseCtx, phaseCancel := context.WithTimeout(retryCtx, o.attemptTimeout)
 		defer phaseCancel()
 
+		// Keep shutdown from closing clients under this attempt
+		done := o.clients.Begin()
+		defer done()
+
+		o.status.MarkRunning(phaseName)
+
 		startTime := time.Now()
 		err := fn(phaseCtx)
 		duration := time.Since(startTime).Seconds()
@@ -375,14 +970,28 @@ func (o *Orchestrator) initializeWithRetry(ctx context.Context, phaseName string
 		o.metrics.RecordBootstrapPhase(ctx, phaseName, duration)
 
 		if err != nil {
-			// Check if it's a context cancellation - if so, don't retry
-			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			// Categorize as a provisioning failure unless err says otherwise
+			o.status.MarkFailed(phaseName, pkgerrors.NewBootstrapError(phaseName, err))
+
+			// Stop once the whole phase is canceled or out of time. An
+			// attempt that merely ran out its own timeout, e.g. while a
+			// per-resource retry kept failing, counts as a failed attempt
+			if retryCtx.Err() != nil {
 				o.logger.Warn("initialization phase context canceled",
 					"phase", phaseName,
 					"error", err)
 				return backoff.Permanent(err) // Don't retry on context cancellation
 			}
 
+			// Invalid config, missing namespaces etc. won't fix themselves
+			if pkgerrors.IsPermanent(err) {
+				o.logger.Error("initialization phase failed permanently, not retrying",
+					"phase", phaseName,
+					"error", err)
+				o.metrics.RecordBootstrapError(ctx, phaseName)
+				return backoff.Permanent(err)
+			}
+
 			o.logger.Warn("initialization phase failed, will retry",
 				"phase", phaseName,
 				"error", err,
@@ -394,11 +1003,28 @@ func (o *Orchestrator) initializeWithRetry(ctx context.Context, phaseName string
 		o.logger.Info("initialization phase complete",
 			"phase", phaseName,
 			"duration_seconds", duration)
+
+		if o.status.MarkSucceeded(phaseName) {
+			o.metrics.RecordBootstrapSuccess(ctx, o.status.LastSuccess())
+			o.logger.Info("all critical bootstrap phases complete")
+			for _, fn := range o.onComplete {
+				fn()
+			}
+		}
 		return nil
 	}
 
 	// Run with backoff
-	if err := backoff.Retry(operation, backoff.WithContext(backoffStrategy, retryCtx)); err != nil {
+	attempts := 0
+	counted := func() error {
+		attempts++
+		return operation()
+	}
+	err := backoff.Retry(counted, backoff.WithContext(backoffStrategy, retryCtx))
+	span.SetAttributes(attribute.Int("attempts", attempts))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "phase failed")
 		o.logger.Error("initialization phase failed after retries",
 			"phase", phaseName,
 			"error", err)
@@ -0,0 +1,588 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/supervisor"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/telemetry"
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// testConfig loads a minimal config that sends every dependency, probe and
+// provisioning call to 127.0.0.1:port.
+func testConfig(t *testing.T, port int) *config.Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := fmt.Sprintf(`bootstrap:
+  dependencies:
+    - name: postgres
+      type: tcp
+      address: 127.0.0.1:%[1]d
+  pulsar:
+    admin_url: http://127.0.0.1:%[1]d
+    namespaces: [events]
+  postgres:
+    host: 127.0.0.1
+    port: %[1]d
+    password: secret
+  redis:
+    host: 127.0.0.1
+    port: %[1]d
+`, port)
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	return cfg
+}
+
+// newTestOrchestrator returns an orchestrator for cfg that drops logs and
+// traces, along with the reader collecting its metrics.
+func newTestOrchestrator(t *testing.T, cfg *config.Config) (*Orchestrator, *sdkmetric.ManualReader) {
+	t.Helper()
+	metrics, reader, err := telemetry.NewTestMetrics()
+	if err != nil {
+		t.Fatalf("NewTestMetrics: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewOrchestrator(cfg, logger, noop.NewTracerProvider().Tracer("test"), metrics), reader
+}
+
+// gaugePoints returns the data points recorded on the int64 gauge name.
+func gaugePoints(t *testing.T, reader *sdkmetric.ManualReader, name string) []metricdata.DataPoint[int64] {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect metrics: %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m.Data.(metricdata.Gauge[int64]).DataPoints
+			}
+		}
+	}
+	return nil
+}
+
+// histogramPoints returns the data points recorded on the float64 histogram name.
+func histogramPoints(t *testing.T, reader *sdkmetric.ManualReader, name string) []metricdata.HistogramDataPoint[float64] {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect metrics: %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m.Data.(metricdata.Histogram[float64]).DataPoints
+			}
+		}
+	}
+	return nil
+}
+
+// waitFor polls cond until it holds, failing the test after 5 seconds.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// hangingListener accepts connections and never answers on them, so any
+// client talking to it blocks until its context ends. It returns the port.
+func hangingListener(t *testing.T) int {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+	return lis.Addr().(*net.TCPAddr).Port
+}
+
+func TestRunAbortsAtMaxTotalDuration(t *testing.T) {
+	cfg := testConfig(t, hangingListener(t))
+	cfg.Bootstrap.MaxTotalDuration = 300 * time.Millisecond
+	o, _ := newTestOrchestrator(t, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- o.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, pkgerrors.ErrBootstrapDeadline) {
+			t.Fatalf("Run error = %v, want %v", err, pkgerrors.ErrBootstrapDeadline)
+		}
+		if elapsed := time.Since(start); elapsed < cfg.Bootstrap.MaxTotalDuration {
+			t.Errorf("Run returned after %s, before the %s deadline", elapsed, cfg.Bootstrap.MaxTotalDuration)
+		}
+		if got := pkgerrors.ExitCode(err); got != pkgerrors.ExitProvisioning {
+			t.Errorf("ExitCode = %d, want %d", got, pkgerrors.ExitProvisioning)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run did not abort at the total deadline; in-flight phases were not canceled")
+	}
+}
+
+func TestSuccessfulBootstrapRecordsLastSuccess(t *testing.T) {
+	o, reader := newTestOrchestrator(t, testConfig(t, hangingListener(t)))
+	o.status.Register("initialize_nats", true)
+	o.status.Register("warm_cache", false)
+
+	succeed := func(context.Context) error { return nil }
+	o.initializeWithRetry(context.Background(), "initialize_nats", succeed)
+
+	snap := o.Status().Snapshot()
+	if !snap.Complete {
+		t.Fatal("bootstrap not complete after every critical phase succeeded")
+	}
+	want := o.Status().LastSuccess().Unix()
+	if snap.LastSuccessTimestamp != want || want == 0 {
+		t.Errorf("LastSuccessTimestamp = %d, want %d", snap.LastSuccessTimestamp, want)
+	}
+
+	var got []int64
+	for _, dp := range gaugePoints(t, reader, "raymond.bootstrap.last_success_timestamp") {
+		got = append(got, dp.Value)
+	}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("last_success_timestamp gauge = %v, want [%d]", got, want)
+	}
+}
+
+func TestInitializeWithRetryStopsOnPermanentError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{"permanent", pkgerrors.Permanent(errors.New("invalid subject")), 1},
+		{"transient", errors.New("dial tcp 127.0.0.1:4222: connection refused"), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(t, hangingListener(t))
+			cfg.Bootstrap.RetryAttempts = 2
+			cfg.Bootstrap.RetryBackoff = time.Millisecond
+			o, _ := newTestOrchestrator(t, cfg)
+			o.status.Register("initialize_nats", true)
+
+			fail := func(context.Context) error { return tt.err }
+			o.initializeWithRetry(context.Background(), "initialize_nats", fail)
+
+			phase := o.Status().Snapshot().Phases[0]
+			if phase.Attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", phase.Attempts, tt.wantAttempts)
+			}
+			if phase.State != PhaseFailed {
+				t.Errorf("state = %s, want %s", phase.State, PhaseFailed)
+			}
+		})
+	}
+}
+
+func TestAbortCancelsInFlightPhase(t *testing.T) {
+	o, _ := newTestOrchestrator(t, testConfig(t, hangingListener(t)))
+	o.status.Register("initialize_nats", true)
+
+	started := make(chan struct{})
+	block := func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	// A canceled parent context alone gives the phase a 30s grace period
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		o.initializeWithRetry(ctx, "initialize_nats", block)
+		close(done)
+	}()
+
+	<-started
+	cancel()
+	o.Abort()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("in-flight phase still running after Abort")
+	}
+}
+
+func TestMonitorRecordsOutageDuration(t *testing.T) {
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	o, reader := newTestOrchestrator(t, testConfig(t, hangingListener(t)))
+	o.checker.SetDependencies([]config.DependencyConfig{{Name: "api", Type: "http", URL: srv.URL}})
+	o.monitorInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go o.monitorDependencies(ctx, &supervisor.Heartbeat{})
+
+	// Let the monitor see the outage before the dependency recovers
+	waitFor(t, "the outage to be seen", func() bool {
+		results, _, ok := o.checker.Monitored().Load()
+		return ok && !results["api"].OK
+	})
+	time.Sleep(50 * time.Millisecond)
+	healthy.Store(true)
+
+	var outage metricdata.HistogramDataPoint[float64]
+	waitFor(t, "the outage duration to be recorded", func() bool {
+		points := histogramPoints(t, reader, "raymond.dependency.outage_duration_seconds")
+		if len(points) == 0 {
+			return false
+		}
+		outage = points[0]
+		return true
+	})
+
+	if service, _ := outage.Attributes.Value("service"); service.AsString() != "api" {
+		t.Errorf("outage service = %q, want %q", service.AsString(), "api")
+	}
+	if outage.Count != 1 || outage.Sum < 0.05 {
+		t.Errorf("outage count, sum = %d, %.3fs, want 1 outage of at least 0.05s", outage.Count, outage.Sum)
+	}
+}
+
+func TestRunIDTagsEveryPhaseOfOneRun(t *testing.T) {
+	cfg := testConfig(t, hangingListener(t))
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	runIDs := make(map[string]bool)
+	for range 2 {
+		o, _ := newTestOrchestrator(t, cfg)
+		o.tracer = tracer
+		succeed := func(context.Context) error { return nil }
+		o.runPhase(context.Background(), "initialize_nats", succeed)
+		o.runPhase(context.Background(), "initialize_pulsar", succeed)
+
+		if got := o.Status().Snapshot().RunID; got != o.RunID() {
+			t.Errorf("status run_id = %q, want %q", got, o.RunID())
+		}
+		runIDs[o.RunID()] = true
+	}
+	if len(runIDs) != 2 {
+		t.Errorf("run IDs = %v, want a different one per run", runIDs)
+	}
+
+	// Each run's two phase spans carry that run's ID
+	spansPerRun := make(map[string]int)
+	for _, span := range recorder.Ended() {
+		for _, attr := range span.Attributes() {
+			if attr.Key == "bootstrap.run_id" {
+				spansPerRun[attr.Value.AsString()]++
+			}
+		}
+	}
+	for id := range runIDs {
+		if spansPerRun[id] != 2 {
+			t.Errorf("spans tagged with run %s = %d, want 2", id, spansPerRun[id])
+		}
+	}
+}
+
+func TestInitializeWithRetryRespectsRetryAttempts(t *testing.T) {
+	for _, retries := range []int{0, 1, 3} {
+		t.Run(fmt.Sprintf("retry_attempts=%d", retries), func(t *testing.T) {
+			cfg := testConfig(t, hangingListener(t))
+			cfg.Bootstrap.RetryAttempts = retries
+			cfg.Bootstrap.RetryBackoff = time.Millisecond
+			o, _ := newTestOrchestrator(t, cfg)
+			o.status.Register("initialize_nats", true)
+
+			var calls atomic.Int64
+			fail := func(context.Context) error {
+				calls.Add(1)
+				return errors.New("dial tcp 127.0.0.1:4222: connection refused")
+			}
+			o.initializeWithRetry(context.Background(), "initialize_nats", fail)
+
+			if got, want := calls.Load(), int64(retries+1); got != want {
+				t.Errorf("attempts = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestPhaseRetriesWhenResourceRetriesRunOut(t *testing.T) {
+	var requests atomic.Int64
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer admin.Close()
+
+	cfg := testConfig(t, hangingListener(t))
+	cfg.Bootstrap.Pulsar.AdminURL = admin.URL
+	cfg.Bootstrap.RetryAttempts = 2
+	cfg.Bootstrap.RetryBackoff = time.Millisecond
+	o, _ := newTestOrchestrator(t, cfg)
+	o.attemptTimeout = 200 * time.Millisecond
+	o.status.Register("initialize_pulsar", true)
+
+	// Each attempt keeps retrying the namespace until the attempt times out,
+	// which must count against retry_attempts rather than end the phase
+	o.initializeWithRetry(context.Background(), "initialize_pulsar", o.initializePulsar)
+
+	phase := o.Status().Snapshot().Phases[0]
+	if phase.State != PhaseFailed || phase.Attempts != 3 {
+		t.Errorf("phase = %s after %d attempts, want failed after 3", phase.State, phase.Attempts)
+	}
+	if got := requests.Load(); got <= int64(phase.Attempts) {
+		t.Errorf("admin requests = %d, want the namespace retried within each attempt", got)
+	}
+}
+
+// logRecorder is a slog.Handler keeping the messages logged at warning level
+// and above.
+type logRecorder struct {
+	mu       sync.Mutex
+	warnings []string
+}
+
+func (r *logRecorder) Enabled(context.Context, slog.Level) bool { return true }
+func (r *logRecorder) WithAttrs([]slog.Attr) slog.Handler       { return r }
+func (r *logRecorder) WithGroup(string) slog.Handler            { return r }
+
+func (r *logRecorder) Handle(_ context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelWarn {
+		r.mu.Lock()
+		r.warnings = append(r.warnings, record.Message)
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// count returns how many warnings were logged with message.
+func (r *logRecorder) count(message string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, m := range r.warnings {
+		if m == message {
+			n++
+		}
+	}
+	return n
+}
+
+func TestMonitorSummarizesLongOutage(t *testing.T) {
+	var probes atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	o, _ := newTestOrchestrator(t, testConfig(t, hangingListener(t)))
+	o.checker.SetDependencies([]config.DependencyConfig{{Name: "api", Type: "http", URL: srv.URL}})
+	logs := &logRecorder{}
+	o.logger = slog.New(logs)
+	o.monitorInterval = 10 * time.Millisecond
+	o.downSummaryInterval = 100 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go o.monitorDependencies(ctx, &supervisor.Heartbeat{})
+	time.Sleep(350 * time.Millisecond)
+	cancel()
+
+	if probes.Load() < 10 {
+		t.Fatalf("only %d monitor cycles ran", probes.Load())
+	}
+	// One warning on the transition, then one summary per interval
+	if got := logs.count("dependency unhealthy"); got != 1 {
+		t.Errorf("%q warnings = %d, want 1", "dependency unhealthy", got)
+	}
+	if got := logs.count("dependency still unhealthy"); got < 2 || got > 3 {
+		t.Errorf("%q warnings = %d, want 2 or 3 over 350ms", "dependency still unhealthy", got)
+	}
+}
+
+func TestRunFailsWhenCriticalDependencyStaysDown(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer api.Close()
+
+	cfg := testConfig(t, hangingListener(t))
+	cfg.Bootstrap.FailOnCriticalUnreachable = true
+	cfg.Bootstrap.Timeout = 300 * time.Millisecond
+	cfg.Server.ShutdownTimeout = 100 * time.Millisecond
+	o, _ := newTestOrchestrator(t, cfg)
+	o.checker.SetDependencies([]config.DependencyConfig{{Name: "api", Type: "http", URL: api.URL, Critical: true}})
+	o.monitorInterval = 20 * time.Millisecond
+	// Stop the phases still retrying against the hanging listener
+	t.Cleanup(o.Abort)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- o.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, pkgerrors.ErrDependencyUnhealthy) {
+			t.Fatalf("Run error = %v, want %v", err, pkgerrors.ErrDependencyUnhealthy)
+		}
+		if elapsed := time.Since(start); elapsed < cfg.Bootstrap.Timeout {
+			t.Errorf("Run failed after %s, before the %s timeout", elapsed, cfg.Bootstrap.Timeout)
+		}
+		if got := pkgerrors.ExitCode(err); got == pkgerrors.ExitOK {
+			t.Errorf("ExitCode = %d, want non-zero", got)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run kept going with a critical dependency down past the timeout")
+	}
+}
+
+func TestRunWaitsForCriticalDependenciesBeforeProvisioning(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer api.Close()
+
+	cfg := testConfig(t, hangingListener(t))
+	cfg.Bootstrap.FailOnCriticalTimeout = true
+	cfg.Bootstrap.Timeout = 300 * time.Millisecond
+	cfg.Server.ShutdownTimeout = 100 * time.Millisecond
+	o, _ := newTestOrchestrator(t, cfg)
+	o.checker.SetDependencies([]config.DependencyConfig{{Name: "api", Type: "http", URL: api.URL, Critical: true}})
+	t.Cleanup(o.Abort)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- o.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		var depErr *pkgerrors.DependencyError
+		if !errors.Is(err, pkgerrors.ErrDependencyUnhealthy) || !errors.As(err, &depErr) || depErr.Service != "api" {
+			t.Fatalf("Run error = %v, want %v for api", err, pkgerrors.ErrDependencyUnhealthy)
+		}
+		if phases := o.Status().Snapshot().Phases; len(phases) != 0 {
+			t.Errorf("phases = %+v, want provisioning not started", phases)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run kept going with a critical dependency down past the timeout")
+	}
+}
+
+func TestPhaseSpansAreChildrenOfBootstrapRun(t *testing.T) {
+	cfg := testConfig(t, hangingListener(t))
+	recorder := tracetest.NewSpanRecorder()
+	o, _ := newTestOrchestrator(t, cfg)
+	o.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	phases := []string{"initialize_nats", "initialize_pulsar"}
+	ctx, run := o.startSpan(context.Background(), "bootstrap.run")
+	var wg sync.WaitGroup
+	for _, name := range phases {
+		o.status.Register(name, true)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.initializeWithRetry(ctx, name, func(context.Context) error { return nil })
+		}()
+	}
+	wg.Wait()
+	run.End()
+
+	parents := make(map[string]trace.SpanContext)
+	for _, span := range recorder.Ended() {
+		parents[span.Name()] = span.Parent()
+		if span.SpanContext().TraceID() != run.SpanContext().TraceID() {
+			t.Errorf("span %q is in trace %s, want the bootstrap.run trace %s",
+				span.Name(), span.SpanContext().TraceID(), run.SpanContext().TraceID())
+		}
+	}
+	for _, name := range phases {
+		parent, ok := parents["bootstrap."+name]
+		if !ok {
+			t.Errorf("no span for phase %s", name)
+			continue
+		}
+		if parent.SpanID() != run.SpanContext().SpanID() {
+			t.Errorf("bootstrap.%s parent = %s, want bootstrap.run %s", name, parent.SpanID(), run.SpanContext().SpanID())
+		}
+	}
+}
+
+func TestApplyConfigKeepsDiscoveredDependencies(t *testing.T) {
+	cfg := testConfig(t, 1)
+	cfg.Health.Discovery.Source = "consul"
+	o, _ := newTestOrchestrator(t, cfg)
+
+	// As the consul watch source would
+	discovered := []config.DependencyConfig{{Name: "discovered", Type: "tcp", Address: "10.0.0.1:5432"}}
+	o.checker.SetDependencies(discovered)
+
+	o.ApplyConfig(cfg)
+	if got := o.checker.Dependencies(); len(got) != 1 || got[0].Name != "discovered" {
+		t.Errorf("dependencies after ApplyConfig = %+v, want %+v", got, discovered)
+	}
+}
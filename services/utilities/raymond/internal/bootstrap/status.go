@@ -0,0 +1,199 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+)
+
+// PhaseState is the lifecycle state of a bootstrap phase.
+type PhaseState string
+
+// Phase states reported by the status tracker.
+const (
+	PhasePending   PhaseState = "pending"
+	PhaseRunning   PhaseState = "running"
+	PhaseSucceeded PhaseState = "succeeded"
+	PhaseFailed    PhaseState = "failed"
+)
+
+// PhaseStatus reports the progress of a single bootstrap phase.
+type PhaseStatus struct {
+	Name      string         `json:"name"`
+	State     PhaseState     `json:"state"`
+	Critical  bool           `json:"critical"`
+	Attempts  int            `json:"attempts"`
+	Error     string         `json:"error,omitempty"`
+	ErrorCode pkgerrors.Code `json:"error_code,omitempty"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// StatusSnapshot is a point-in-time view of bootstrap progress.
+type StatusSnapshot struct {
+	RunID                string        `json:"run_id"`
+	StartedAt            time.Time     `json:"started_at"`
+	Complete             bool          `json:"complete"`
+	LastSuccessTimestamp int64         `json:"last_success_timestamp,omitempty"`
+	Phases               []PhaseStatus `json:"phases"`
+}
+
+// Status tracks bootstrap phase progress. It is safe for concurrent use.
+type Status struct {
+	mu          sync.RWMutex
+	runID       string
+	startedAt   time.Time
+	order       []string
+	phases      map[string]*PhaseStatus
+	lastSuccess time.Time
+}
+
+// NewStatus creates an empty status tracker for the bootstrap run runID.
+func NewStatus(runID string) *Status {
+	return &Status{runID: runID, phases: make(map[string]*PhaseStatus)}
+}
+
+// Register adds a phase in the pending state. Critical phases must all succeed
+// for bootstrap to be considered complete.
+func (s *Status) Register(name string, critical bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.startedAt.IsZero() {
+		s.startedAt = time.Now()
+	}
+	if _, exists := s.phases[name]; !exists {
+		s.order = append(s.order, name)
+	}
+	s.phases[name] = &PhaseStatus{
+		Name:      name,
+		State:     PhasePending,
+		Critical:  critical,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// MarkRunning records the start of an attempt for the phase.
+func (s *Status) MarkRunning(name string) {
+	s.update(name, func(p *PhaseStatus) {
+		p.State = PhaseRunning
+		p.Attempts++
+	})
+}
+
+// MarkFailed records a failed attempt for the phase with the error's category
+// and its message, with credentials redacted. A BootstrapError's "bootstrap
+// phase <name>" prefix is left out of the message, since the phase is already
+// known.
+func (s *Status) MarkFailed(name string, err error) {
+	message := err.Error()
+	var bootErr *pkgerrors.BootstrapError
+	if errors.As(err, &bootErr) {
+		message = bootErr.Err.Error()
+	}
+
+	s.update(name, func(p *PhaseStatus) {
+		p.State = PhaseFailed
+		p.Error = pkgerrors.Redact(message)
+		p.ErrorCode = pkgerrors.CodeOf(err)
+	})
+}
+
+// MarkSucceeded records a successful phase. It reports true when this success
+// completes the set of critical phases, in which case the completion time is
+// recorded as the last successful bootstrap.
+func (s *Status) MarkSucceeded(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, exists := s.phases[name]
+	if !exists {
+		return false
+	}
+	p.State = PhaseSucceeded
+	p.Error = ""
+	p.ErrorCode = ""
+	p.UpdatedAt = time.Now()
+
+	if !p.Critical || !s.criticalSucceeded() {
+		return false
+	}
+	s.lastSuccess = p.UpdatedAt
+	return true
+}
+
+// LastSuccess returns when all critical phases last completed successfully.
+func (s *Status) LastSuccess() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastSuccess
+}
+
+// Snapshot returns a copy of the current bootstrap progress.
+func (s *Status) Snapshot() StatusSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := StatusSnapshot{
+		RunID:     s.runID,
+		StartedAt: s.startedAt,
+		Complete:  s.criticalSucceeded(),
+		Phases:    make([]PhaseStatus, 0, len(s.order)),
+	}
+	if !s.lastSuccess.IsZero() {
+		snap.LastSuccessTimestamp = s.lastSuccess.Unix()
+	}
+	for _, name := range s.order {
+		snap.Phases = append(snap.Phases, *s.phases[name])
+	}
+	return snap
+}
+
+// BlockingPhases describes each critical phase that has not succeeded yet,
+// e.g. "phase initialize_nats is running", for explaining why the service is
+// not ready.
+func (s *Status) BlockingPhases() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var reasons []string
+	for _, name := range s.order {
+		p := s.phases[name]
+		switch {
+		case !p.Critical || p.State == PhaseSucceeded:
+			continue
+		case p.State == PhaseFailed && p.Error != "":
+			reasons = append(reasons, fmt.Sprintf("phase %s failed: %s", name, p.Error))
+		default:
+			reasons = append(reasons, fmt.Sprintf("phase %s is %s", name, p.State))
+		}
+	}
+	return reasons
+}
+
+// update applies fn to the named phase under the lock.
+func (s *Status) update(name string, fn func(*PhaseStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, exists := s.phases[name]; exists {
+		fn(p)
+		p.UpdatedAt = time.Now()
+	}
+}
+
+// criticalSucceeded reports whether every critical phase has succeeded.
+// Callers must hold s.mu.
+func (s *Status) criticalSucceeded() bool {
+	if len(s.phases) == 0 {
+		return false
+	}
+	for _, p := range s.phases {
+		if p.Critical && p.State != PhaseSucceeded {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package clients
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sony/gobreaker"
+)
+
+// Option configures optional client behavior.
+type Option func(*options)
+
+// options holds the settings shared by all clients.
+type options struct {
+	onStateChange func(name string, from, to gobreaker.State)
+}
+
+// WithBreakerStateChange registers fn to be called whenever the client's
+// circuit breaker changes state, e.g. to record a metric or alert on trips.
+func WithBreakerStateChange(fn func(name string, from, to gobreaker.State)) Option {
+	return func(o *options) {
+		o.onStateChange = fn
+	}
+}
+
+// newBreaker creates the circuit breaker guarding a client's calls.
+func newBreaker(name string, opts []Option) *gobreaker.CircuitBreaker {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:          name,
+		MaxRequests:   3,
+		Interval:      10 * time.Second,
+		Timeout:       30 * time.Second,
+		OnStateChange: o.onStateChange,
+	})
+}
+
+// BreakerState is a point-in-time view of a client's circuit breaker.
+type BreakerState struct {
+	Name                string `json:"name"`
+	State               string `json:"state"`
+	Requests            uint32 `json:"requests"`
+	TotalFailures       uint32 `json:"total_failures"`
+	ConsecutiveFailures uint32 `json:"consecutive_failures"`
+}
+
+func breakerState(cb *gobreaker.CircuitBreaker) BreakerState {
+	counts := cb.Counts()
+	return BreakerState{
+		Name:                cb.Name(),
+		State:               cb.State().String(),
+		Requests:            counts.Requests,
+		TotalFailures:       counts.TotalFailures,
+		ConsecutiveFailures: counts.ConsecutiveFailures,
+	}
+}
+
+// BreakerSource is implemented by clients that guard calls with a circuit
+// breaker.
+type BreakerSource interface {
+	BreakerState() BreakerState
+}
+
+// BreakerRegistry collects the circuit breakers of the clients currently in
+// use. Registering a client under a name that is already present replaces
+// the previous one, so a reconnected client reports its own breaker.
+type BreakerRegistry struct {
+	mu      sync.RWMutex
+	sources map[string]BreakerSource
+}
+
+// NewBreakerRegistry creates an empty breaker registry.
+func NewBreakerRegistry() *BreakerRegistry {
+	return &BreakerRegistry{sources: make(map[string]BreakerSource)}
+}
+
+// Register adds or replaces the breaker reported under name.
+func (r *BreakerRegistry) Register(name string, src BreakerSource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources[name] = src
+}
+
+// Snapshot returns the state of every registered breaker, sorted by name.
+func (r *BreakerRegistry) Snapshot() []BreakerState {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.sources))
+	for name := range r.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	states := make([]BreakerState, 0, len(names))
+	for _, name := range names {
+		states = append(states, r.sources[name].BreakerState())
+	}
+	r.mu.RUnlock()
+	return states
+}
@@ -0,0 +1,128 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// errInvalidTopic marks topic names that can't be provisioned as configured.
+var errInvalidTopic = errors.New("invalid topic")
+
+// AdminError is a non-success response from the Pulsar admin REST API.
+type AdminError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *AdminError) Error() string {
+	return fmt.Sprintf("pulsar admin: unexpected status code: %d: %s", e.StatusCode, e.Message)
+}
+
+// permanent reports whether retrying the request cannot succeed: client
+// errors other than timeouts and rate limiting.
+func (e *AdminError) permanent() bool {
+	return e.StatusCode/100 == 4 &&
+		e.StatusCode != http.StatusRequestTimeout &&
+		e.StatusCode != http.StatusTooManyRequests
+}
+
+// permanentPulsarResults are Pulsar result codes that retrying cannot fix.
+var permanentPulsarResults = map[pulsar.Result]bool{
+	pulsar.InvalidConfiguration:    true,
+	pulsar.AuthenticationError:     true,
+	pulsar.AuthorizationError:      true,
+	pulsar.InvalidTopicName:        true,
+	pulsar.InvalidURL:              true,
+	pulsar.TopicNotFound:           true,
+	pulsar.OperationNotSupported:   true,
+	pulsar.UnsupportedVersionError: true,
+}
+
+// permanentPulsarMessages are fragments of broker error messages that retrying
+// cannot fix. The producer path often reports these as plain strings.
+var permanentPulsarMessages = []string{
+	"namespace not found",
+	"topicnotfound",
+	"authorizationerror",
+	"authenticationerror",
+	"invalidtopicname",
+	"policies not found",
+}
+
+// markNATS marks err with pkgerrors.Permanent if retrying the JetStream
+// operation can't fix it, so callers only need pkgerrors.IsPermanent.
+func markNATS(err error) error {
+	if isPermanentNATSError(err) {
+		return pkgerrors.Permanent(err)
+	}
+	return err
+}
+
+// markPulsar marks err with pkgerrors.Permanent if retrying the Pulsar
+// operation can't fix it, so callers only need pkgerrors.IsPermanent.
+func markPulsar(err error) error {
+	if isPermanentPulsarError(err) {
+		return pkgerrors.Permanent(err)
+	}
+	return err
+}
+
+// isPermanentNATSError reports whether err from a JetStream operation is caused
+// by invalid input or server configuration, such as an invalid subject or
+// JetStream being disabled, rather than a transient connectivity problem.
+func isPermanentNATSError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, jetstream.ErrStreamNameRequired) ||
+		errors.Is(err, jetstream.ErrInvalidStreamName) ||
+		errors.Is(err, jetstream.ErrInvalidSubject) ||
+		errors.Is(err, jetstream.ErrJetStreamNotEnabled) ||
+		errors.Is(err, jetstream.ErrJetStreamNotEnabledForAccount) {
+		return true
+	}
+
+	var apiErr *jetstream.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusBadRequest
+	}
+
+	return false
+}
+
+// isPermanentPulsarError reports whether err from a Pulsar operation is caused
+// by invalid input, missing tenants/namespaces or denied access rather than a
+// transient connectivity problem.
+func isPermanentPulsarError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pulsarErr *pulsar.Error
+	if errors.As(err, &pulsarErr) && permanentPulsarResults[pulsarErr.Result()] {
+		return true
+	}
+
+	var adminErr *AdminError
+	if errors.As(err, &adminErr) && adminErr.permanent() {
+		return true
+	}
+	if errors.Is(err, errInvalidTopic) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range permanentPulsarMessages {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func TestMarkNATS(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		permanent bool
+	}{
+		{"invalid subject", fmt.Errorf("create stream EVENTS: %w", jetstream.ErrInvalidSubject), true},
+		{"jetstream disabled", jetstream.ErrJetStreamNotEnabled, true},
+		{"bad request", &jetstream.APIError{Code: http.StatusBadRequest, Description: "subjects overlap"}, true},
+		{"server error", &jetstream.APIError{Code: http.StatusServiceUnavailable}, false},
+		{"connection refused", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, false},
+		{"timeout", context.DeadlineExceeded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := markNATS(tt.err)
+			if got := pkgerrors.IsPermanent(err); got != tt.permanent {
+				t.Errorf("IsPermanent(markNATS(%v)) = %v, want %v", tt.err, got, tt.permanent)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("markNATS(%v) no longer wraps the original error", tt.err)
+			}
+		})
+	}
+}
+
+func TestMarkPulsar(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		permanent bool
+	}{
+		{"namespace not found", &AdminError{StatusCode: http.StatusNotFound, Message: "Namespace not found"}, true},
+		{"forbidden", &AdminError{StatusCode: http.StatusForbidden}, true},
+		{"rate limited", &AdminError{StatusCode: http.StatusTooManyRequests}, false},
+		{"request timeout", &AdminError{StatusCode: http.StatusRequestTimeout}, false},
+		{"broker unavailable", &AdminError{StatusCode: http.StatusServiceUnavailable}, false},
+		{"invalid topic", fmt.Errorf("%w: missing namespace", errInvalidTopic), true},
+		{"producer message", errors.New("server error: TopicNotFound: topic does not exist"), true},
+		{"connection refused", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := markPulsar(tt.err)
+			if got := pkgerrors.IsPermanent(err); got != tt.permanent {
+				t.Errorf("IsPermanent(markPulsar(%v)) = %v, want %v", tt.err, got, tt.permanent)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Lifecycle tracks open clients and in-flight work that uses them. On
+// Shutdown it waits for the work to finish and then closes the clients in
+// reverse creation order, so a client is never closed under a caller that is
+// still using it.
+type Lifecycle struct {
+	mu       sync.Mutex
+	entries  []*lifecycleEntry
+	work     sync.WaitGroup
+	shutdown bool
+}
+
+type lifecycleEntry struct {
+	name    string
+	closeFn func() error
+	once    sync.Once
+	err     error
+}
+
+func (e *lifecycleEntry) close() error {
+	e.once.Do(func() { e.err = e.closeFn() })
+	return e.err
+}
+
+// NewLifecycle creates an empty client lifecycle manager.
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{}
+}
+
+// Begin marks the start of work that uses tracked clients. The returned
+// function must be called when the work is done. Work begun after Shutdown
+// has started is not waited for.
+func (l *Lifecycle) Begin() (done func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.shutdown {
+		return func() {}
+	}
+	l.work.Add(1)
+
+	var once sync.Once
+	return func() { once.Do(l.work.Done) }
+}
+
+// Register tracks a client to be closed on Shutdown. The returned release
+// function closes the client right away and stops tracking it, for clients
+// that are only needed for the duration of a phase. Clients registered after
+// Shutdown has started are closed immediately.
+func (l *Lifecycle) Register(name string, closeFn func() error) (release func() error) {
+	entry := &lifecycleEntry{name: name, closeFn: closeFn}
+
+	l.mu.Lock()
+	if l.shutdown {
+		l.mu.Unlock()
+		entry.close()
+		return func() error { return nil }
+	}
+	l.entries = append(l.entries, entry)
+	l.mu.Unlock()
+
+	return func() error {
+		l.remove(entry)
+		return entry.close()
+	}
+}
+
+// remove stops tracking entry.
+func (l *Lifecycle) remove(entry *lifecycleEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, e := range l.entries {
+		if e == entry {
+			l.entries = append(l.entries[:i], l.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// Shutdown waits for in-flight work to finish, or for ctx to be done, and then
+// closes every tracked client in reverse creation order. Close errors are
+// joined.
+func (l *Lifecycle) Shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	l.shutdown = true
+	l.mu.Unlock()
+
+	workDone := make(chan struct{})
+	go func() {
+		l.work.Wait()
+		close(workDone)
+	}()
+
+	var errs []error
+	select {
+	case <-workDone:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("waiting for in-flight client work: %w", ctx.Err()))
+	}
+
+	l.mu.Lock()
+	entries := l.entries
+	l.entries = nil
+	l.mu.Unlock()
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		if err := entries[i].close(); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", entries[i].name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
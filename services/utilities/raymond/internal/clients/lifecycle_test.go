@@ -0,0 +1,105 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// closeRecorder records the order in which clients are closed.
+type closeRecorder struct {
+	mu     sync.Mutex
+	closed []string
+}
+
+func (r *closeRecorder) closer(name string) func() error {
+	return func() error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.closed = append(r.closed, name)
+		return nil
+	}
+}
+
+func (r *closeRecorder) order() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.closed)
+}
+
+func TestLifecycleShutdownClosesInReverseOrder(t *testing.T) {
+	var rec closeRecorder
+	l := NewLifecycle()
+	l.Register("nats", rec.closer("nats"))
+	l.Register("pulsar", rec.closer("pulsar"))
+	release := l.Register("postgres", rec.closer("postgres"))
+	l.Register("redis", rec.closer("redis"))
+
+	// Released clients close right away and not again on shutdown
+	release()
+
+	if err := l.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	want := []string{"postgres", "redis", "pulsar", "nats"}
+	if got := rec.order(); !slices.Equal(got, want) {
+		t.Errorf("close order = %v, want %v", got, want)
+	}
+}
+
+func TestLifecycleShutdownWaitsForWork(t *testing.T) {
+	var rec closeRecorder
+	l := NewLifecycle()
+	l.Register("nats", rec.closer("nats"))
+
+	done := l.Begin()
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- l.Shutdown(context.Background()) }()
+
+	time.Sleep(50 * time.Millisecond)
+	if got := rec.order(); len(got) != 0 {
+		t.Fatalf("clients closed while work was in flight: %v", got)
+	}
+
+	done()
+	if err := <-shutdown; err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if got := rec.order(); !slices.Equal(got, []string{"nats"}) {
+		t.Errorf("closed = %v, want [nats]", got)
+	}
+}
+
+func TestLifecycleShutdownGivesUpOnWork(t *testing.T) {
+	var rec closeRecorder
+	l := NewLifecycle()
+	l.Register("nats", rec.closer("nats"))
+	l.Begin() // never finishes
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := l.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if got := rec.order(); !slices.Equal(got, []string{"nats"}) {
+		t.Errorf("closed = %v, want [nats]", got)
+	}
+}
+
+func TestLifecycleRegisterAfterShutdown(t *testing.T) {
+	var rec closeRecorder
+	l := NewLifecycle()
+	if err := l.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	l.Register("redis", rec.closer("redis"))
+	if got := rec.order(); !slices.Equal(got, []string{"redis"}) {
+		t.Errorf("closed = %v, want [redis]", got)
+	}
+}
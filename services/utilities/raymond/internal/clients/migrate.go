@@ -0,0 +1,141 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+)
+
+// migrationLockKey is the Postgres advisory lock held while migrating, so
+// only one replica applies migrations at a time.
+const migrationLockKey int64 = 0x7261796d6f6e64 // "raymond"
+
+// Migration is a single SQL migration file. Version is the file name without
+// the .sql extension; migrations are applied in version order.
+type Migration struct {
+	Version string
+	SQL     string
+}
+
+// LoadMigrations reads the .sql files in dir, ordered by file name (e.g.
+// 0001_create_agents.sql, 0002_add_index.sql). A missing dir is a permanent
+// error rather than an empty migration set, so a mistyped path isn't
+// mistaken for having nothing to apply.
+func LoadMigrations(dir string) ([]Migration, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, pkgerrors.Permanent(fmt.Errorf("migrations dir: %w", err))
+	}
+	if !info.IsDir() {
+		return nil, pkgerrors.Permanent(fmt.Errorf("migrations dir %s is not a directory", dir))
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("list migrations: %w", err)
+	}
+	sort.Strings(paths)
+
+	migrations := make([]Migration, 0, len(paths))
+	for _, path := range paths {
+		sql, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read migration: %w", err)
+		}
+		migrations = append(migrations, Migration{
+			Version: strings.TrimSuffix(filepath.Base(path), ".sql"),
+			SQL:     string(sql),
+		})
+	}
+	return migrations, nil
+}
+
+// Migrate applies the migrations not yet recorded in schema_migrations, each
+// in its own transaction together with its record, and returns the versions
+// that were pending. It holds an advisory lock for the duration, so replicas
+// starting together migrate one after the other and later ones find nothing
+// left to do. With dryRun, the pending versions are returned without applying
+// them.
+func (c *PostgresClient) Migrate(ctx context.Context, migrations []Migration, dryRun bool) ([]string, error) {
+	result, err := c.cb.Execute(func() (interface{}, error) {
+		conn, err := c.pool.Acquire(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("acquire connection: %w", err)
+		}
+		defer conn.Release()
+
+		// Advisory locks belong to the session, so lock and unlock on this conn
+		if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+			return nil, fmt.Errorf("acquire migration lock: %w", err)
+		}
+		defer func() {
+			// A conn still holding the lock must not go back to the pool,
+			// or replicas would wait on it until the conn is recycled
+			unlockCtx := context.WithoutCancel(ctx)
+			if _, err := conn.Exec(unlockCtx, "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+				conn.Conn().Close(unlockCtx)
+			}
+		}()
+
+		if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    text PRIMARY KEY,
+			applied_at timestamptz NOT NULL DEFAULT now()
+		)`); err != nil {
+			return nil, fmt.Errorf("create schema_migrations: %w", err)
+		}
+
+		rows, err := conn.Query(ctx, "SELECT version FROM schema_migrations")
+		if err != nil {
+			return nil, fmt.Errorf("query applied migrations: %w", err)
+		}
+		applied := make(map[string]bool)
+		for rows.Next() {
+			var version string
+			if err := rows.Scan(&version); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan applied migration: %w", err)
+			}
+			applied[version] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("query applied migrations: %w", err)
+		}
+
+		var pending []string
+		for _, m := range migrations {
+			if applied[m.Version] {
+				continue
+			}
+			pending = append(pending, m.Version)
+			if dryRun {
+				continue
+			}
+
+			tx, err := conn.Begin(ctx)
+			if err != nil {
+				return pending, fmt.Errorf("begin migration %s: %w", m.Version, err)
+			}
+			if _, err := tx.Exec(ctx, m.SQL); err != nil {
+				tx.Rollback(ctx)
+				return pending, fmt.Errorf("apply migration %s: %w", m.Version, err)
+			}
+			if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", m.Version); err != nil {
+				tx.Rollback(ctx)
+				return pending, fmt.Errorf("record migration %s: %w", m.Version, err)
+			}
+			if err := tx.Commit(ctx); err != nil {
+				return pending, fmt.Errorf("commit migration %s: %w", m.Version, err)
+			}
+		}
+		return pending, nil
+	})
+
+	pending, _ := result.([]string)
+	return pending, err
+}
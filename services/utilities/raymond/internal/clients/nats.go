@@ -2,6 +2,7 @@ package clients
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -18,8 +19,9 @@ type NATSClient struct {
 	cb   *gobreaker.CircuitBreaker
 }
 
-// NewNATSClient creates a new NATS client with connection.
-func NewNATSClient(ctx context.Context, cfg config.NATSConfig) (*NATSClient, error) {
+// NewNATSClient creates a new NATS client with connection. The initial
+// connect gives up when ctx is done.
+func NewNATSClient(ctx context.Context, cfg config.NATSConfig, clientOpts ...Option) (*NATSClient, error) {
 	opts := []nats.Option{
 		nats.Name("raymond-bootstrap"),
 		nats.Timeout(10 * time.Second),
@@ -27,7 +29,7 @@ func NewNATSClient(ctx context.Context, cfg config.NATSConfig) (*NATSClient, err
 		nats.MaxReconnects(5),
 	}
 
-	conn, err := nats.Connect(cfg.URL, opts...)
+	conn, err := connectNATS(ctx, cfg.URL, opts)
 	if err != nil {
 		return nil, fmt.Errorf("nats connect failed: %w", err)
 	}
@@ -38,12 +40,14 @@ func NewNATSClient(ctx context.Context, cfg config.NATSConfig) (*NATSClient, err
 		return nil, fmt.Errorf("jetstream context failed: %w", err)
 	}
 
-	cb := gobreaker.NewCircuitBreaker(gobreaker.Settings{
-		Name:        "nats-jetstream",
-		MaxRequests: 3,
-		Interval:    10 * time.Second,
-		Timeout:     30 * time.Second,
-	})
+	// jetstream.New succeeds against a server without JetStream; stream
+	// creation would then time out on every retry, so check up front.
+	if err := checkJetStream(ctx, js); err != nil {
+		conn.Close()
+		return nil, markNATS(err)
+	}
+
+	cb := newBreaker("nats-jetstream", clientOpts)
 
 	return &NATSClient{
 		conn: conn,
@@ -52,6 +56,52 @@ func NewNATSClient(ctx context.Context, cfg config.NATSConfig) (*NATSClient, err
 	}, nil
 }
 
+// connectNATS connects to url, giving up when ctx is done. nats.Connect
+// takes no context, and its timeout option also applies to every reconnect
+// for the life of the connection, so it can't carry ctx's deadline.
+func connectNATS(ctx context.Context, url string, opts []nats.Option) (*nats.Conn, error) {
+	type result struct {
+		conn *nats.Conn
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		conn, err := nats.Connect(url, opts...)
+		done <- result{conn, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.conn, r.err
+	case <-ctx.Done():
+		// Close the connection if the abandoned attempt still succeeds
+		go func() {
+			if r := <-done; r.conn != nil {
+				r.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// checkJetStream verifies the server has JetStream enabled for this account.
+// A disabled JetStream is reported as a permanent error.
+func checkJetStream(ctx context.Context, js jetstream.JetStream) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := js.AccountInfo(ctx)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, jetstream.ErrJetStreamNotEnabled),
+		errors.Is(err, jetstream.ErrJetStreamNotEnabledForAccount):
+		return fmt.Errorf("jetstream is not enabled on the NATS server: %w", err)
+	default:
+		return fmt.Errorf("jetstream account info failed: %w", err)
+	}
+}
+
 // CreateStream creates a JetStream stream with the given configuration.
 func (c *NATSClient) CreateStream(ctx context.Context, cfg config.StreamConfig) error {
 	_, err := c.cb.Execute(func() (interface{}, error) {
@@ -87,7 +137,84 @@ func (c *NATSClient) CreateStream(ctx context.Context, cfg config.StreamConfig)
 		return nil, nil
 	})
 
-	return err
+	// A bad subject or disabled JetStream fails the same way every time
+	return markNATS(err)
+}
+
+// CreateConsumer creates a durable pull consumer, or updates it if it
+// already exists.
+func (c *NATSClient) CreateConsumer(ctx context.Context, cfg config.ConsumerConfig) error {
+	_, err := c.cb.Execute(func() (interface{}, error) {
+		ackPolicy := jetstream.AckExplicitPolicy
+		switch cfg.AckPolicy {
+		case "all":
+			ackPolicy = jetstream.AckAllPolicy
+		case "none":
+			ackPolicy = jetstream.AckNonePolicy
+		}
+
+		_, err := c.js.CreateOrUpdateConsumer(ctx, cfg.Stream, jetstream.ConsumerConfig{
+			Durable:       cfg.Name,
+			FilterSubject: cfg.FilterSubject,
+			AckPolicy:     ackPolicy,
+			AckWait:       cfg.AckWait,
+			MaxDeliver:    cfg.MaxDeliver,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create/update consumer: %w", err)
+		}
+		return nil, nil
+	})
+
+	return markNATS(err)
+}
+
+// CreateKV creates a key-value bucket, or updates it if it already exists.
+func (c *NATSClient) CreateKV(ctx context.Context, cfg config.KVConfig) error {
+	_, err := c.cb.Execute(func() (interface{}, error) {
+		replicas := cfg.Replicas
+		if replicas == 0 {
+			replicas = 1
+		}
+
+		_, err := c.js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+			Bucket:   cfg.Bucket,
+			TTL:      cfg.TTL,
+			History:  uint8(cfg.History),
+			Replicas: replicas,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create/update kv bucket: %w", err)
+		}
+		return nil, nil
+	})
+
+	return markNATS(err)
+}
+
+// ConnName returns the name the connection identifies itself with.
+func (c *NATSClient) ConnName() string {
+	return c.conn.Opts.Name
+}
+
+// Stats returns the connection's message, byte and reconnect counters.
+func (c *NATSClient) Stats() nats.Statistics {
+	return c.conn.Stats()
+}
+
+// ConsumerPending returns the number of messages on stream not yet delivered
+// to consumer.
+func (c *NATSClient) ConsumerPending(ctx context.Context, stream, consumer string) (uint64, error) {
+	cons, err := c.js.Consumer(ctx, stream, consumer)
+	if err != nil {
+		return 0, markNATS(fmt.Errorf("get consumer %s/%s: %w", stream, consumer, err))
+	}
+
+	info, err := cons.Info(ctx)
+	if err != nil {
+		return 0, markNATS(fmt.Errorf("consumer info %s/%s: %w", stream, consumer, err))
+	}
+	return info.NumPending, nil
 }
 
 // Close closes the NATS connection.
@@ -96,3 +223,9 @@ func (c *NATSClient) Close() {
 		c.conn.Close()
 	}
 }
+
+// BreakerState reports the current state and counts of the client's circuit
+// breaker.
+func (c *NATSClient) BreakerState() BreakerState {
+	return breakerState(c.cb)
+}
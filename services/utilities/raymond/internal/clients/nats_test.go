@@ -0,0 +1,133 @@
+package clients
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// serveNATSWithoutJetStream starts a minimal NATS server that accepts
+// connections but has no JetStream, so every request gets a no-responders
+// reply, and returns its URL.
+func serveNATSWithoutJetStream(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go serveNATSConn(conn)
+		}
+	}()
+	return "nats://" + lis.Addr().String()
+}
+
+// serveNATSConn speaks just enough of the NATS protocol for a client to
+// connect and publish requests.
+func serveNATSConn(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprint(conn, "INFO {\"server_id\":\"fake\",\"version\":\"2.10.0\",\"proto\":1,\"headers\":true,\"max_payload\":1048576}\r\n")
+
+	r := bufio.NewReader(conn)
+	sid := "1"
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "SUB":
+			sid = fields[len(fields)-1]
+		case "PUB", "HPUB":
+			// PUB subject [reply] size, HPUB subject [reply] hdr_size size
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			if _, err := io.CopyN(io.Discard, r, int64(size)+2); err != nil {
+				return
+			}
+			replyAt := 2
+			if fields[0] == "HPUB" {
+				replyAt = 3
+			}
+			if len(fields) > replyAt {
+				// Status 503 is how a server reports no responders
+				header := "NATS/1.0 503\r\n\r\n"
+				fmt.Fprintf(conn, "HMSG %s %s %d %d\r\n%s\r\n", fields[2], sid, len(header), len(header), header)
+			}
+		}
+	}
+}
+
+func TestNewNATSClientWithoutJetStream(t *testing.T) {
+	url := serveNATSWithoutJetStream(t)
+
+	start := time.Now()
+	client, err := NewNATSClient(context.Background(), config.NATSConfig{URL: url})
+	if err == nil {
+		client.Close()
+		t.Fatal("NewNATSClient succeeded without JetStream")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("NewNATSClient took %v, want a prompt failure", elapsed)
+	}
+	if !errors.Is(err, jetstream.ErrJetStreamNotEnabled) || !strings.Contains(err.Error(), "jetstream is not enabled") {
+		t.Errorf("error = %v, want jetstream not enabled", err)
+	}
+	if !pkgerrors.IsPermanent(err) {
+		t.Errorf("error %v is retryable, want permanent", err)
+	}
+}
+
+func TestNewNATSClientStopsAtPhaseDeadline(t *testing.T) {
+	// Accepts connections but never sends INFO, so the connect hangs until
+	// the client's own 10s timeout
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer lis.Close()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = NewNATSClient(ctx, config.NATSConfig{URL: "nats://" + lis.Addr().String()})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("NewNATSClient returned after %v, want at the 200ms phase deadline", elapsed)
+	}
+}
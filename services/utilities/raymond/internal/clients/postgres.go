@@ -2,10 +2,15 @@ package clients
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sony/gobreaker"
 )
@@ -16,22 +21,43 @@ type PostgresClient struct {
 	cb   *gobreaker.CircuitBreaker
 }
 
-// NewPostgresClient creates a new Postgres client with connection pool.
-func NewPostgresClient(ctx context.Context, cfg config.PostgresConfig) (*PostgresClient, error) {
-	connString := fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Database, cfg.SSLMode,
-	)
+// NewPostgresClient creates a new Postgres client with connection pool. When
+// ssl_mode enables TLS, the minimum version is taken from tlsCfg, or left at
+// pgx's default if tlsCfg is nil. Creating the pool and the initial ping are
+// bounded by ctx.
+func NewPostgresClient(ctx context.Context, cfg config.PostgresConfig, tlsCfg *tls.Config, opts ...Option) (*PostgresClient, error) {
+	// Build the URL rather than formatting it, so credentials containing
+	// characters such as @, : or / are escaped
+	connURL := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Path:     "/" + cfg.Database,
+		RawQuery: url.Values{"sslmode": {cfg.SSLMode}}.Encode(),
+	}
 
-	poolCfg, err := pgxpool.ParseConfig(connString)
+	poolCfg, err := pgxpool.ParseConfig(connURL.String())
 	if err != nil {
 		return nil, fmt.Errorf("parse postgres config: %w", err)
 	}
 
+	if tlsCfg != nil {
+		if poolCfg.ConnConfig.TLSConfig != nil {
+			poolCfg.ConnConfig.TLSConfig.MinVersion = tlsCfg.MinVersion
+		}
+		for _, fallback := range poolCfg.ConnConfig.Fallbacks {
+			if fallback.TLSConfig != nil {
+				fallback.TLSConfig.MinVersion = tlsCfg.MinVersion
+			}
+		}
+	}
+
 	poolCfg.MaxConns = int32(cfg.MaxConns)
 	poolCfg.MinConns = int32(cfg.MinConns)
 	poolCfg.MaxConnLifetime = 1 * time.Hour
 	poolCfg.MaxConnIdleTime = 30 * time.Minute
+	// Background connects by the pool don't see ctx, so bound them explicitly
+	poolCfg.ConnConfig.ConnectTimeout = 10 * time.Second
 
 	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
@@ -44,12 +70,15 @@ func NewPostgresClient(ctx context.Context, cfg config.PostgresConfig) (*Postgre
 		return nil, fmt.Errorf("ping postgres: %w", err)
 	}
 
-	cb := gobreaker.NewCircuitBreaker(gobreaker.Settings{
-		Name:        "postgres",
-		MaxRequests: 3,
-		Interval:    10 * time.Second,
-		Timeout:     30 * time.Second,
-	})
+	// Eagerly establish MinConns so early requests don't pay connect latency
+	if cfg.WarmPool {
+		if err := warmPool(ctx, pool, cfg.MinConns); err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("warm postgres pool: %w", err)
+		}
+	}
+
+	cb := newBreaker("postgres", opts)
 
 	return &PostgresClient{
 		pool: pool,
@@ -57,6 +86,27 @@ func NewPostgresClient(ctx context.Context, cfg config.PostgresConfig) (*Postgre
 	}, nil
 }
 
+// warmPool holds n connections at once, forcing the pool to open each of them,
+// then releases them back so they remain as idle connections.
+func warmPool(ctx context.Context, pool *pgxpool.Pool, n int) error {
+	conns := make([]*pgxpool.Conn, 0, n)
+	defer func() {
+		for _, conn := range conns {
+			conn.Release()
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		conn, err := pool.Acquire(ctx)
+		if err != nil {
+			return fmt.Errorf("acquire connection %d/%d: %w", i+1, n, err)
+		}
+		conns = append(conns, conn)
+	}
+
+	return nil
+}
+
 // ValidateSchema checks if a schema exists in the database.
 func (c *PostgresClient) ValidateSchema(ctx context.Context, schema string) error {
 	_, err := c.cb.Execute(func() (interface{}, error) {
@@ -67,10 +117,15 @@ func (c *PostgresClient) ValidateSchema(ctx context.Context, schema string) erro
 			return nil, fmt.Errorf("query schema: %w", err)
 		}
 		if !exists {
-			return nil, fmt.Errorf("schema %s does not exist", schema)
+			return nil, pkgerrors.Permanent(fmt.Errorf("schema %s does not exist", schema))
 		}
 		return nil, nil
 	})
+
+	// Bad credentials or a missing database won't fix themselves
+	if pkgerrors.IsPermanentDriverError(err) {
+		return pkgerrors.Permanent(err)
+	}
 	return err
 }
 
@@ -85,3 +140,9 @@ func (c *PostgresClient) Close() {
 		c.pool.Close()
 	}
 }
+
+// BreakerState reports the current state and counts of the client's circuit
+// breaker.
+func (c *PostgresClient) BreakerState() BreakerState {
+	return breakerState(c.cb)
+}
@@ -1,23 +1,44 @@
 package clients
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
 	"github.com/sony/gobreaker"
 )
 
-// PulsarClient wraps Apache Pulsar admin and producer clients.
+// PulsarClient wraps Apache Pulsar admin and producer clients. The producer
+// client connects to the broker's binary protocol at ServiceURL; topic
+// provisioning goes through the admin REST API at AdminURL.
 type PulsarClient struct {
-	client pulsar.Client
-	cb     *gobreaker.CircuitBreaker
+	client     pulsar.Client
+	cb         *gobreaker.CircuitBreaker
+	admin      *adminLimiter
+	adminURL   string
+	adminHTTP  *http.Client
+	tenant     string
+	namespaces []string
+
+	flushTimeout time.Duration
+	mu           sync.Mutex
+	producers    map[string]pulsar.Producer
 }
 
-// NewPulsarClient creates a new Pulsar client.
-func NewPulsarClient(ctx context.Context, cfg config.PulsarConfig) (*PulsarClient, error) {
+// NewPulsarClient creates a new Pulsar client. Admin calls are bounded by the
+// context they are called with.
+func NewPulsarClient(ctx context.Context, cfg config.PulsarConfig, opts ...Option) (*PulsarClient, error) {
 	serviceURL := cfg.ServiceURL
 	if serviceURL == "" {
 		serviceURL = "pulsar://arc-strange:6650"
@@ -29,43 +50,209 @@ func NewPulsarClient(ctx context.Context, cfg config.PulsarConfig) (*PulsarClien
 		ConnectionTimeout: 10 * time.Second,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("pulsar client creation failed: %w", err)
+		return nil, markPulsar(fmt.Errorf("pulsar client creation failed: %w", err))
 	}
 
-	cb := gobreaker.NewCircuitBreaker(gobreaker.Settings{
-		Name:        "pulsar",
-		MaxRequests: 3,
-		Interval:    10 * time.Second,
-		Timeout:     30 * time.Second,
-	})
+	cb := newBreaker("pulsar", opts)
 
 	return &PulsarClient{
-		client: client,
-		cb:     cb,
+		client:     client,
+		cb:         cb,
+		admin:      newAdminLimiter(cfg.AdminRateLimit),
+		adminURL:   strings.TrimSuffix(cfg.AdminURL, "/"),
+		adminHTTP:  &http.Client{Timeout: 30 * time.Second},
+		tenant:     cfg.Tenant,
+		namespaces: cfg.Namespaces,
+
+		flushTimeout: cfg.FlushTimeout,
+		producers:    make(map[string]pulsar.Producer),
 	}, nil
 }
 
-// CreateTopic creates a partitioned topic (admin operation requires HTTP API).
-// For simplicity, we'll just verify connectivity here. Full admin operations
-// would require using the Pulsar admin HTTP API.
+// CreateTopic creates topic through the admin API, as a partitioned topic
+// with the given number of partitions or as a non-partitioned topic when
+// partitions is 0. A topic that already exists counts as created. The topic
+// may be a full name (persistent://tenant/namespace/topic) or relative to the
+// configured tenant (namespace/topic), and must be in a configured namespace.
 func (c *PulsarClient) CreateTopic(ctx context.Context, topic string, partitions int) error {
-	_, err := c.cb.Execute(func() (interface{}, error) {
-		// Create a producer to verify the topic exists (Pulsar auto-creates topics)
-		producer, err := c.client.CreateProducer(pulsar.ProducerOptions{
-			Topic: topic,
-		})
+	path, err := c.topicPath(topic)
+	if err != nil {
+		return pkgerrors.Permanent(err)
+	}
+
+	release, err := c.admin.acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("wait for admin rate limit: %w", err)
+	}
+	defer release()
+
+	var body []byte
+	if partitions > 0 {
+		path += "/partitions"
+		body = []byte(strconv.Itoa(partitions))
+	}
+
+	_, err = c.cb.Execute(func() (interface{}, error) {
+		err := c.adminPut(ctx, path, body)
 		if err != nil {
-			return nil, fmt.Errorf("create producer for topic %s: %w", topic, err)
+			return nil, fmt.Errorf("create topic %s: %w", topic, err)
 		}
-		producer.Close()
 		return nil, nil
 	})
-	return err
+
+	// 4xx responses such as a missing namespace or denied access won't change
+	return markPulsar(err)
+}
+
+// CreateNamespace creates namespace under the configured tenant through the
+// admin API. A namespace that already exists counts as created.
+func (c *PulsarClient) CreateNamespace(ctx context.Context, namespace string) error {
+	release, err := c.admin.acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("wait for admin rate limit: %w", err)
+	}
+	defer release()
+
+	_, err = c.cb.Execute(func() (interface{}, error) {
+		err := c.adminPut(ctx, "/admin/v2/namespaces/"+c.tenant+"/"+namespace, nil)
+		if err != nil {
+			return nil, fmt.Errorf("create namespace %s/%s: %w", c.tenant, namespace, err)
+		}
+		return nil, nil
+	})
+	return markPulsar(err)
+}
+
+// topicPath returns the admin API path of topic, e.g.
+// /admin/v2/persistent/arc/events/agent-lifecycle.
+func (c *PulsarClient) topicPath(topic string) (string, error) {
+	domain := "persistent"
+	name := topic
+	if d, rest, ok := strings.Cut(topic, "://"); ok {
+		domain, name = d, rest
+	}
+	if domain != "persistent" && domain != "non-persistent" {
+		return "", fmt.Errorf("%w: %s: unknown topic domain %q", errInvalidTopic, topic, domain)
+	}
+
+	parts := strings.Split(name, "/")
+	if len(parts) == 2 {
+		parts = append([]string{c.tenant}, parts...)
+	}
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return "", fmt.Errorf("%w: %s: want tenant/namespace/topic", errInvalidTopic, topic)
+	}
+
+	tenant, namespace, local := parts[0], parts[1], parts[2]
+	if tenant != c.tenant || !slices.Contains(c.namespaces, namespace) {
+		return "", fmt.Errorf("%w: %s: %s/%s is not a configured namespace", errInvalidTopic, topic, tenant, namespace)
+	}
+	return "/admin/v2/" + domain + "/" + tenant + "/" + namespace + "/" + local, nil
+}
+
+// adminPut sends a PUT to the admin API. A 409 Conflict means the resource
+// already exists and is treated as success.
+func (c *PulsarClient) adminPut(ctx context.Context, path string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.adminURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create admin request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.adminHTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("admin request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict || resp.StatusCode/100 == 2 {
+		return nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &AdminError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+}
+
+// Producer returns a producer for topic, creating it on first use. Producers
+// are flushed and closed by Close.
+func (c *PulsarClient) Producer(topic string) (pulsar.Producer, error) {
+	c.mu.Lock()
+	producer, ok := c.producers[topic]
+	c.mu.Unlock()
+	if ok {
+		return producer, nil
+	}
+
+	// Creating a producer is a broker round trip, so it runs unlocked to
+	// keep other topics and Flush from waiting on it
+	result, err := c.cb.Execute(func() (interface{}, error) {
+		producer, err := c.client.CreateProducer(pulsar.ProducerOptions{Topic: topic})
+		if err != nil {
+			return nil, fmt.Errorf("create producer for topic %s: %w", topic, err)
+		}
+		return producer, nil
+	})
+	if err != nil {
+		return nil, markPulsar(err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Another caller may have created one for the same topic meanwhile
+	if existing, ok := c.producers[topic]; ok {
+		result.(pulsar.Producer).Close()
+		return existing, nil
+	}
+	producer = result.(pulsar.Producer)
+	c.producers[topic] = producer
+	return producer, nil
+}
+
+// Flush waits until the messages buffered by every open producer have been
+// persisted by the broker, or ctx is done.
+func (c *PulsarClient) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	producers := make([]pulsar.Producer, 0, len(c.producers))
+	for _, producer := range c.producers {
+		producers = append(producers, producer)
+	}
+	c.mu.Unlock()
+
+	var errs []error
+	for _, producer := range producers {
+		if err := producer.FlushWithCtx(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("flush producer for topic %s: %w", producer.Topic(), err))
+		}
+	}
+	return errors.Join(errs...)
 }
 
-// Close closes the Pulsar client.
-func (c *PulsarClient) Close() {
+// Close flushes the open producers, waiting up to the configured flush
+// timeout (0 means no limit), then closes them and the Pulsar client.
+// Messages still buffered when the timeout passes are dropped.
+func (c *PulsarClient) Close() error {
+	ctx := context.Background()
+	if c.flushTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.flushTimeout)
+		defer cancel()
+	}
+	err := c.Flush(ctx)
+
+	c.mu.Lock()
+	for topic, producer := range c.producers {
+		producer.Close()
+		delete(c.producers, topic)
+	}
+	c.mu.Unlock()
+
 	if c.client != nil {
 		c.client.Close()
 	}
+	return err
+}
+
+// BreakerState reports the current state and counts of the client's circuit
+// breaker.
+func (c *PulsarClient) BreakerState() BreakerState {
+	return breakerState(c.cb)
 }
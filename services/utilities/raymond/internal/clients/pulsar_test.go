@@ -0,0 +1,212 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"golang.org/x/sync/errgroup"
+)
+
+// newTestPulsarClient returns a client whose admin calls go to adminURL. The
+// broker connection is lazy and never used.
+func newTestPulsarClient(t *testing.T, adminURL string, limit config.AdminRateLimitConfig) *PulsarClient {
+	t.Helper()
+	client, err := NewPulsarClient(context.Background(), config.PulsarConfig{
+		AdminURL:       adminURL,
+		ServiceURL:     "pulsar://127.0.0.1:6650",
+		Tenant:         "arc",
+		Namespaces:     []string{"events"},
+		AdminRateLimit: limit,
+	})
+	if err != nil {
+		t.Fatalf("NewPulsarClient: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// concurrencyCounter is an admin API that records the most requests it served
+// at once.
+type concurrencyCounter struct {
+	inFlight atomic.Int64
+	peak     atomic.Int64
+	total    atomic.Int64
+}
+
+func (c *concurrencyCounter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	c.total.Add(1)
+	time.Sleep(20 * time.Millisecond)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// createTopics creates n topics concurrently.
+func createTopics(t *testing.T, client *PulsarClient, n int) {
+	t.Helper()
+	var g errgroup.Group
+	for i := range n {
+		g.Go(func() error {
+			return client.CreateTopic(context.Background(), fmt.Sprintf("events/topic-%d", i), 0)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("CreateTopic: %v", err)
+	}
+}
+
+func TestPulsarAdminConcurrencyCap(t *testing.T) {
+	admin := &concurrencyCounter{}
+	srv := httptest.NewServer(admin)
+	defer srv.Close()
+
+	client := newTestPulsarClient(t, srv.URL, config.AdminRateLimitConfig{MaxConcurrent: 2})
+	createTopics(t, client, 10)
+
+	if got := admin.total.Load(); got != 10 {
+		t.Errorf("admin calls = %d, want 10", got)
+	}
+	if got := admin.peak.Load(); got > 2 {
+		t.Errorf("peak concurrent admin calls = %d, want at most 2", got)
+	}
+}
+
+func TestPulsarAdminRateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var calls []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, time.Now())
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := newTestPulsarClient(t, srv.URL, config.AdminRateLimitConfig{RequestsPerSecond: 20})
+	start := time.Now()
+	createTopics(t, client, 5)
+
+	// 5 calls at 20/s are spaced 50ms apart, the first one immediately
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("5 admin calls took %s, want at least 200ms at 20 requests/s", elapsed)
+	}
+	if len(calls) != 5 {
+		t.Errorf("admin calls = %d, want 5", len(calls))
+	}
+}
+
+// closeLog records the order in which fake producers and clients are flushed
+// and closed.
+type closeLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *closeLog) add(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+// fakeProducer is a producer with buffered messages that a flush persists
+// after flushDelay.
+type fakeProducer struct {
+	pulsar.Producer
+	topic      string
+	log        *closeLog
+	flushDelay time.Duration
+	buffered   atomic.Int64
+}
+
+func (p *fakeProducer) Topic() string { return p.topic }
+
+func (p *fakeProducer) FlushWithCtx(ctx context.Context) error {
+	select {
+	case <-time.After(p.flushDelay):
+		p.buffered.Store(0)
+		p.log.add("flush " + p.topic)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *fakeProducer) Close() { p.log.add("close " + p.topic) }
+
+// fakePulsarClient records when it is closed.
+type fakePulsarClient struct {
+	pulsar.Client
+	log *closeLog
+}
+
+func (c *fakePulsarClient) Close() { c.log.add("close client") }
+
+func TestPulsarCloseFlushesProducers(t *testing.T) {
+	tests := []struct {
+		name         string
+		flushDelay   time.Duration
+		flushTimeout time.Duration
+		wantFlushed  bool
+	}{
+		{"flush within timeout", 20 * time.Millisecond, time.Second, true},
+		{"no timeout", 20 * time.Millisecond, 0, true},
+		{"flush timed out", time.Hour, 50 * time.Millisecond, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &closeLog{}
+			producer := &fakeProducer{topic: "bootstrap-events", log: log, flushDelay: tt.flushDelay}
+			producer.buffered.Store(3)
+			client := &PulsarClient{
+				client:       &fakePulsarClient{log: log},
+				flushTimeout: tt.flushTimeout,
+				producers:    map[string]pulsar.Producer{producer.topic: producer},
+			}
+
+			start := time.Now()
+			err := client.Close()
+			if tt.wantFlushed {
+				if err != nil {
+					t.Fatalf("Close: %v", err)
+				}
+				if got := producer.buffered.Load(); got != 0 {
+					t.Errorf("buffered messages after Close = %d, want 0", got)
+				}
+			} else {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("Close error = %v, want %v", err, context.DeadlineExceeded)
+				}
+				if elapsed := time.Since(start); elapsed > time.Second {
+					t.Errorf("Close took %v, want it to give up after the %v flush timeout", elapsed, tt.flushTimeout)
+				}
+			}
+
+			want := []string{"flush bootstrap-events", "close bootstrap-events", "close client"}
+			if !tt.wantFlushed {
+				want = want[1:]
+			}
+			if fmt.Sprint(log.events) != fmt.Sprint(want) {
+				t.Errorf("events = %v, want %v", log.events, want)
+			}
+			if len(client.producers) != 0 {
+				t.Errorf("producers after Close = %v, want none", client.producers)
+			}
+		})
+	}
+}
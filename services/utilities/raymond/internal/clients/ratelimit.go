@@ -0,0 +1,73 @@
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+// adminLimiter bounds how fast and how many admin API calls run at once, so
+// provisioning many topics doesn't overwhelm the broker's admin endpoint.
+type adminLimiter struct {
+	sem      chan struct{} // nil means no concurrency cap
+	interval time.Duration // minimum spacing between calls; 0 means no rate limit
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// newAdminLimiter creates a limiter from cfg. Zero values disable the
+// corresponding limit.
+func newAdminLimiter(cfg config.AdminRateLimitConfig) *adminLimiter {
+	l := &adminLimiter{}
+	if cfg.MaxConcurrent > 0 {
+		l.sem = make(chan struct{}, cfg.MaxConcurrent)
+	}
+	if cfg.RequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / cfg.RequestsPerSecond)
+	}
+	return l
+}
+
+// acquire waits for a concurrency slot and for the call's turn under the rate
+// limit. The returned function releases the slot.
+func (l *adminLimiter) acquire(ctx context.Context) (release func(), err error) {
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release = func() {
+		if l.sem != nil {
+			<-l.sem
+		}
+	}
+
+	if l.interval > 0 {
+		l.mu.Lock()
+		now := time.Now()
+		start := l.next
+		if start.Before(now) {
+			start = now
+		}
+		l.next = start.Add(l.interval)
+		l.mu.Unlock()
+
+		if wait := time.Until(start); wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return release, nil
+}
@@ -2,6 +2,7 @@ package clients
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"time"
 
@@ -16,18 +17,26 @@ type RedisClient struct {
 	cb     *gobreaker.CircuitBreaker
 }
 
-// NewRedisClient creates a new Redis client.
-func NewRedisClient(ctx context.Context, cfg config.RedisConfig) (*RedisClient, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
-		Password:     cfg.Password,
-		DB:           cfg.DB,
-		DialTimeout:  5 * time.Second,
-		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 3 * time.Second,
-		PoolSize:     10,
-		MinIdleConns: 2,
-	})
+// NewRedisClient creates a new Redis client. tlsCfg is used when cfg.TLS is set.
+// The initial ping is bounded by ctx, and later commands by the context they
+// are called with.
+func NewRedisClient(ctx context.Context, cfg config.RedisConfig, tlsCfg *tls.Config, clientOpts ...Option) (*RedisClient, error) {
+	opts := &redis.Options{
+		Addr:                  fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Password:              cfg.Password,
+		DB:                    cfg.DB,
+		DialTimeout:           5 * time.Second,
+		ReadTimeout:           3 * time.Second,
+		WriteTimeout:          3 * time.Second,
+		ContextTimeoutEnabled: true,
+		PoolSize:              10,
+		MinIdleConns:          2,
+	}
+	if cfg.TLS {
+		opts.TLSConfig = tlsCfg.Clone()
+		opts.TLSConfig.ServerName = cfg.Host
+	}
+	client := redis.NewClient(opts)
 
 	// Test connection
 	if err := client.Ping(ctx).Err(); err != nil {
@@ -35,12 +44,7 @@ func NewRedisClient(ctx context.Context, cfg config.RedisConfig) (*RedisClient,
 		return nil, fmt.Errorf("redis ping failed: %w", err)
 	}
 
-	cb := gobreaker.NewCircuitBreaker(gobreaker.Settings{
-		Name:        "redis",
-		MaxRequests: 3,
-		Interval:    10 * time.Second,
-		Timeout:     30 * time.Second,
-	})
+	cb := newBreaker("redis", clientOpts)
 
 	return &RedisClient{
 		client: client,
@@ -82,3 +86,9 @@ func (c *RedisClient) Close() error {
 	}
 	return nil
 }
+
+// BreakerState reports the current state and counts of the client's circuit
+// breaker.
+func (c *RedisClient) BreakerState() BreakerState {
+	return breakerState(c.cb)
+}
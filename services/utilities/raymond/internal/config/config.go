@@ -1,27 +1,87 @@
 package config
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
 	"github.com/spf13/viper"
 )
 
+// supportedConfigTypes lists the config file formats that can be loaded.
+var supportedConfigTypes = map[string]bool{
+	"yaml": true,
+	"yml":  true,
+	"json": true,
+	"toml": true,
+}
+
 // Load reads configuration from file and environment variables.
 // Environment variables take precedence and use the format: SECTION_KEY (e.g., SERVER_PORT).
+// The file format is inferred from the file extension.
 func Load(configPath string) (*Config, error) {
+	return LoadWithType(configPath, "")
+}
+
+// LoadWithType is like Load but uses configType (yaml, json or toml) as the file
+// format instead of inferring it from the extension. This allows loading
+// extensionless files such as mounted secrets. An empty configType infers the
+// format from the extension.
+func LoadWithType(configPath, configType string) (*Config, error) {
+	return LoadWithOptions(configPath, LoadOptions{ConfigType: configType})
+}
+
+// LoadOptions controls how LoadWithOptions reads the config file.
+type LoadOptions struct {
+	// ConfigType overrides the format inferred from the file extension.
+	ConfigType string
+	// AllowMissing loads defaults and environment variables only when the
+	// config file does not exist, instead of failing.
+	AllowMissing bool
+	// SecretResolver fetches values written as infisical://<path>. When nil,
+	// a resolver is built from INFISICAL_URL if it is set.
+	SecretResolver SecretResolver
+}
+
+// LoadWithOptions is like Load but with explicit control over the file format
+// and whether a missing file is fatal.
+func LoadWithOptions(configPath string, opts LoadOptions) (*Config, error) {
 	v := viper.New()
 
 	// Set defaults
 	setDefaults(v)
 
 	// Read from config file if provided
-	if configPath != "" {
+	readFile := configPath != ""
+	if readFile {
+		var err error
+		if readFile, err = checkConfigPath(configPath, opts.AllowMissing); err != nil {
+			return nil, err
+		}
+	}
+
+	if readFile {
+		configType := opts.ConfigType
+		if configType == "" {
+			configType = strings.TrimPrefix(filepath.Ext(configPath), ".")
+		}
+		configType = strings.ToLower(configType)
+		if !supportedConfigTypes[configType] {
+			return nil, fmt.Errorf("%w: unsupported config format %q for %s (supported: yaml, json, toml)",
+				pkgerrors.ErrConfigInvalid, configType, configPath)
+		}
+
 		v.SetConfigFile(configPath)
+		v.SetConfigType(configType)
 		if err := v.ReadInConfig(); err != nil {
-			return nil, fmt.Errorf("failed to read config file: %w", err)
+			return nil, fmt.Errorf("%w: failed to read config file: %w", pkgerrors.ErrConfigInvalid, err)
 		}
 	}
 
@@ -32,45 +92,120 @@ func Load(configPath string) (*Config, error) {
 	// Unmarshal into struct
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		return nil, fmt.Errorf("%w: failed to unmarshal config: %w", pkgerrors.ErrConfigInvalid, err)
+	}
+
+	// Expand ${VAR} references in string values
+	expandEnv(&cfg)
+
+	// Fetch infisical:// references so plaintext secrets stay out of the
+	// file and the environment
+	resolver := opts.SecretResolver
+	if resolver == nil {
+		if r := NewInfisicalResolverFromEnv(); r != nil {
+			resolver = r
+		}
+	}
+	if err := resolveSecrets(context.Background(), &cfg, resolver); err != nil {
+		return nil, err
 	}
 
 	// Validate configuration
-	validate := validator.New()
-	if err := validate.Struct(&cfg); err != nil {
-		return nil, fmt.Errorf("config validation failed: %w", err)
+	if err := validateConfig(&cfg); err != nil {
+		return nil, err
 	}
 
 	return &cfg, nil
 }
 
+// checkConfigPath reports whether configPath should be read, returning an
+// actionable error when it is missing or is a directory. A missing file is not
+// an error when allowMissing is set.
+func checkConfigPath(configPath string, allowMissing bool) (bool, error) {
+	info, err := os.Stat(configPath)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if allowMissing {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: config file %s does not exist; create it (see config.example.yaml) or pass the correct path",
+			pkgerrors.ErrConfigInvalid, configPath)
+	case err != nil:
+		return false, fmt.Errorf("%w: failed to access config file: %w", pkgerrors.ErrConfigInvalid, err)
+	case info.IsDir():
+		return false, fmt.Errorf("%w: config path %s is a directory; point it at a file such as %s",
+			pkgerrors.ErrConfigInvalid, configPath, filepath.Join(configPath, "config.yaml"))
+	}
+	return true, nil
+}
+
 // setDefaults configures sensible defaults for the service.
 func setDefaults(v *viper.Viper) {
 	// Server defaults
 	v.SetDefault("server.port", 8081)
 	v.SetDefault("server.read_timeout", 10*time.Second)
 	v.SetDefault("server.write_timeout", 10*time.Second)
+	v.SetDefault("server.max_header_bytes", 1<<20) // net/http default
 	v.SetDefault("server.shutdown_timeout", 30*time.Second)
 	v.SetDefault("server.enable_pprof", false)
+	v.SetDefault("server.grpc_health_port", 0)
+	v.SetDefault("server.health_path_prefix", "")
+	v.SetDefault("server.deep_health_cache_ttl", 5*time.Second)
+	v.SetDefault("server.enable_compression", false)
+	v.SetDefault("server.compression_min_size", 1024)
+	v.SetDefault("server.admin_token", "") // Admin endpoints disabled
 
 	// Telemetry defaults
 	v.SetDefault("telemetry.otlp_endpoint", "arc-widow:4317")
+	v.SetDefault("telemetry.trace_endpoint", "") // Falls back to otlp_endpoint
+	v.SetDefault("telemetry.metric_endpoint", "")
+	v.SetDefault("telemetry.log_endpoint", "")
 	v.SetDefault("telemetry.otlp_insecure", true)
+	v.SetDefault("telemetry.otlp_ca_file", "") // System pool
+	v.SetDefault("telemetry.otlp_client_cert", "")
+	v.SetDefault("telemetry.otlp_client_key", "")
+	v.SetDefault("telemetry.otlp_server_name", "")
 	v.SetDefault("telemetry.service_name", "arc-raymond-bootstrap")
+	v.SetDefault("telemetry.trace_service_name", "") // Falls back to service_name
+	v.SetDefault("telemetry.log_service_name", "")
 	v.SetDefault("telemetry.log_level", "info")
+	v.SetDefault("telemetry.log_sample_rate", 1.0)
+	v.SetDefault("telemetry.histogram_type", "explicit")
+	v.SetDefault("telemetry.startup_selftest", false)
+	v.SetDefault("telemetry.trace_exporter", "otlp")
+	v.SetDefault("telemetry.jaeger_endpoint", "")
+	v.SetDefault("telemetry.trace_sample_ratio", 1.0)
+	v.SetDefault("telemetry.enable_traces", true)
+	v.SetDefault("telemetry.enable_metrics", true)
+	v.SetDefault("telemetry.enable_logs", true)
+	v.SetDefault("telemetry.enable_prometheus", false)
+	v.SetDefault("telemetry.prometheus_path", "/metrics")
+	v.SetDefault("telemetry.log_field_map", map[string]string{})
+	v.SetDefault("telemetry.span_attribute_count_limit", 0)
+	v.SetDefault("telemetry.span_attribute_value_length_limit", 0)
+	v.SetDefault("telemetry.log_attribute_value_length_limit", 0)
 
 	// Bootstrap defaults
 	v.SetDefault("bootstrap.timeout", 5*time.Minute)
+	v.SetDefault("bootstrap.max_total_duration", 0) // No limit
 	v.SetDefault("bootstrap.retry_attempts", 5)
 	v.SetDefault("bootstrap.retry_backoff", 2*time.Second)
+	v.SetDefault("bootstrap.stall_threshold", 5*time.Minute)
+	v.SetDefault("bootstrap.fail_on_critical_timeout", false)
+	v.SetDefault("bootstrap.fail_on_critical_unreachable", false)
 
 	// NATS defaults
 	v.SetDefault("bootstrap.nats.url", "nats://arc-flash:4222")
+	v.SetDefault("bootstrap.nats.consumer_lag.enabled", false)
+	v.SetDefault("bootstrap.nats.consumer_lag.interval", 30*time.Second)
 
 	// Pulsar defaults
 	v.SetDefault("bootstrap.pulsar.admin_url", "http://arc-strange:8080")
 	v.SetDefault("bootstrap.pulsar.service_url", "pulsar://arc-strange:6650")
 	v.SetDefault("bootstrap.pulsar.tenant", "arc")
+	v.SetDefault("bootstrap.pulsar.admin_rate_limit.requests_per_second", 10)
+	v.SetDefault("bootstrap.pulsar.admin_rate_limit.max_concurrent", 4)
+	v.SetDefault("bootstrap.pulsar.flush_timeout", "10s")
 
 	// Postgres defaults
 	v.SetDefault("bootstrap.postgres.host", "arc-oracle")
@@ -80,9 +215,46 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("bootstrap.postgres.ssl_mode", "disable")
 	v.SetDefault("bootstrap.postgres.max_conns", 25)
 	v.SetDefault("bootstrap.postgres.min_conns", 2)
+	v.SetDefault("bootstrap.postgres.warm_pool", false)
+	v.SetDefault("bootstrap.postgres.migrations.enabled", false)
+	v.SetDefault("bootstrap.postgres.migrations.dir", "./migrations")
+	v.SetDefault("bootstrap.postgres.migrations.dry_run", false)
 
 	// Redis defaults
 	v.SetDefault("bootstrap.redis.host", "arc-sonic")
 	v.SetDefault("bootstrap.redis.port", 6379)
 	v.SetDefault("bootstrap.redis.db", 0)
+	v.SetDefault("bootstrap.redis.tls", false)
+
+	// Health defaults
+	v.SetDefault("health.warmup", 30*time.Second)
+	v.SetDefault("health.check_timeout", 5*time.Second)
+	v.SetDefault("health.ready_requires_telemetry", false)
+	v.SetDefault("health.readiness_requires_critical_deps", false)
+	v.SetDefault("health.discovery.source", "static")
+	v.SetDefault("health.discovery.consul_address", "")
+	v.SetDefault("health.discovery.consul_tag", "raymond-probe")
+	v.SetDefault("health.discovery.interval", 30*time.Second)
+	v.SetDefault("health.readiness_delay", 0)
+	v.SetDefault("health.probe_batch_size", 0) // No batching
+	v.SetDefault("health.probe_batch_interval", 0)
+
+	// Security defaults
+	v.SetDefault("security.min_tls_version", "1.2")
+}
+
+// tlsVersions maps min_tls_version values to crypto/tls versions.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSConfig returns a new tls.Config enforcing the configured minimum TLS
+// version, to be used as the base for every outbound TLS connection.
+func (s SecurityConfig) TLSConfig() *tls.Config {
+	minVersion, ok := tlsVersions[s.MinTLSVersion]
+	if !ok {
+		minVersion = tls.VersionTLS12
+	}
+	return &tls.Config{MinVersion: minVersion}
 }
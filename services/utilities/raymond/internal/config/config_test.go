@@ -0,0 +1,208 @@
+package config
+
+import (
+	"crypto/tls"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+)
+
+// The same minimal valid config in every supported format.
+var configFormats = map[string]string{
+	"yaml": `
+server:
+  port: 9090
+bootstrap:
+  timeout: 90s
+  dependencies:
+    - name: nats
+      type: tcp
+      address: arc-flash:4222
+      critical: true
+  pulsar:
+    namespaces: [events, audit]
+  postgres:
+    password: secret
+`,
+	"json": `{
+  "server": {"port": 9090},
+  "bootstrap": {
+    "timeout": "90s",
+    "dependencies": [
+      {"name": "nats", "type": "tcp", "address": "arc-flash:4222", "critical": true}
+    ],
+    "pulsar": {"namespaces": ["events", "audit"]},
+    "postgres": {"password": "secret"}
+  }
+}`,
+	"toml": `
+[server]
+port = 9090
+
+[bootstrap]
+timeout = "90s"
+
+[[bootstrap.dependencies]]
+name = "nats"
+type = "tcp"
+address = "arc-flash:4222"
+critical = true
+
+[bootstrap.pulsar]
+namespaces = ["events", "audit"]
+
+[bootstrap.postgres]
+password = "secret"
+`,
+}
+
+// writeConfig writes content to name in a temporary directory and returns its path.
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadFormatsAreEquivalent(t *testing.T) {
+	want, err := Load(writeConfig(t, "config.yaml", configFormats["yaml"]))
+	if err != nil {
+		t.Fatalf("Load yaml: %v", err)
+	}
+	if want.Server.Port != 9090 || want.Bootstrap.Timeout != 90*time.Second || len(want.Bootstrap.Dependencies) != 1 {
+		t.Fatalf("yaml config not applied: %+v", want)
+	}
+
+	for format, content := range configFormats {
+		t.Run(format+" by extension", func(t *testing.T) {
+			got, err := Load(writeConfig(t, "config."+format, content))
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("config = %+v, want %+v", got, want)
+			}
+		})
+
+		t.Run(format+" by type", func(t *testing.T) {
+			got, err := LoadWithType(writeConfig(t, "raymond-config", content), format)
+			if err != nil {
+				t.Fatalf("LoadWithType: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("config = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsUnsupportedFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		configType string
+	}{
+		{"extension", "config.ini", ""},
+		{"explicit type", "raymond-config", "hcl"},
+		{"no extension", "raymond-config", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadWithType(writeConfig(t, tt.file, configFormats["yaml"]), tt.configType)
+			if !errors.Is(err, pkgerrors.ErrConfigInvalid) {
+				t.Errorf("LoadWithType error = %v, want %v", err, pkgerrors.ErrConfigInvalid)
+			}
+		})
+	}
+}
+
+func TestLoadConfigPathErrors(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.yaml")
+
+	tests := []struct {
+		name    string
+		path    string
+		opts    LoadOptions
+		wantMsg string
+	}{
+		{"missing file", missing, LoadOptions{}, "config file " + missing + " does not exist; create it"},
+		{"directory", dir, LoadOptions{}, "config path " + dir + " is a directory; point it at a file such as " + filepath.Join(dir, "config.yaml")},
+		{"directory with allow missing", dir, LoadOptions{AllowMissing: true}, "is a directory"},
+		// Defaults and environment are loaded instead, and then validated
+		{"allow missing", missing, LoadOptions{AllowMissing: true}, "bootstrap.dependencies is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadWithOptions(tt.path, tt.opts)
+			if !errors.Is(err, pkgerrors.ErrConfigInvalid) {
+				t.Fatalf("LoadWithOptions error = %v, want %v", err, pkgerrors.ErrConfigInvalid)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("LoadWithOptions error = %q, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestSecurityTLSConfig(t *testing.T) {
+	tests := []struct {
+		minTLSVersion string
+		want          uint16
+	}{
+		{"1.2", tls.VersionTLS12},
+		{"1.3", tls.VersionTLS13},
+		{"", tls.VersionTLS12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.minTLSVersion, func(t *testing.T) {
+			got := SecurityConfig{MinTLSVersion: tt.minTLSVersion}.TLSConfig().MinVersion
+			if got != tt.want {
+				t.Errorf("MinVersion = %s, want %s", tls.VersionName(got), tls.VersionName(tt.want))
+			}
+		})
+	}
+}
+
+func TestStallThresholdMustExceedLoopInterval(t *testing.T) {
+	const consumerLag = `
+  nats:
+    consumer_lag:
+      enabled: true
+      interval: 3m
+`
+	tests := []struct {
+		name      string
+		extra     string
+		wantError bool
+	}{
+		{"disabled", "  stall_threshold: 0s\n", false},
+		{"longer than monitor interval", "  stall_threshold: 5m\n", false},
+		{"equal to monitor interval", "  stall_threshold: 30s\n", true},
+		{"shorter than consumer lag interval", "  stall_threshold: 2m" + consumerLag, true},
+		{"longer than consumer lag interval", "  stall_threshold: 5m" + consumerLag, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfig(t, "config.yaml", configFormats["yaml"]+tt.extra))
+			if (err != nil) != tt.wantError {
+				t.Fatalf("Load error = %v, want error %v", err, tt.wantError)
+			}
+			if err != nil && !strings.Contains(err.Error(), "bootstrap.stall_threshold") {
+				t.Errorf("error %q does not name bootstrap.stall_threshold", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+)
+
+// envReference matches a ${VAR} reference. Bare $VAR is left alone, so values
+// such as passwords may contain a literal $.
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces ${VAR} references in every string field of cfg,
+// including strings nested in structs, slices and maps, with values from the
+// environment. Unset variables expand to the empty string.
+func expandEnv(cfg *Config) {
+	expandValue(reflect.ValueOf(cfg).Elem())
+}
+
+// expandValue recursively expands environment references in v.
+func expandValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(expandString(v.String()))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			expandValue(v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			expandValue(v.Index(i))
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		for _, key := range v.MapKeys() {
+			v.SetMapIndex(key, reflect.ValueOf(expandString(v.MapIndex(key).String())).Convert(v.Type().Elem()))
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			expandValue(v.Elem())
+		}
+	}
+}
+
+// expandString replaces the ${VAR} references in s.
+func expandString(s string) string {
+	return envReference.ReplaceAllStringFunc(s, func(ref string) string {
+		return os.Getenv(ref[2 : len(ref)-1])
+	})
+}
@@ -0,0 +1,45 @@
+package config
+
+import "testing"
+
+func TestLoadExpandsEnvReferences(t *testing.T) {
+	t.Setenv("PULSAR_HOST", "pulsar.internal")
+	t.Setenv("NATS_ADDR", "nats.internal:4222")
+	t.Setenv("LOG_MESSAGE_KEY", "message")
+
+	cfg, err := Load(writeConfig(t, "config.yaml", `
+telemetry:
+  log_field_map:
+    msg: ${LOG_MESSAGE_KEY}
+bootstrap:
+  dependencies:
+    - name: nats
+      type: tcp
+      address: ${NATS_ADDR}
+  pulsar:
+    service_url: pulsar://${PULSAR_HOST}:6650
+    namespaces: [events]
+  postgres:
+    password: pa$$word${UNSET_RAYMOND_TEST_VAR}
+`))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		field string
+		got   string
+		want  string
+	}{
+		{"bootstrap.pulsar.service_url", cfg.Bootstrap.Pulsar.ServiceURL, "pulsar://pulsar.internal:6650"},
+		{"bootstrap.dependencies[0].address", cfg.Bootstrap.Dependencies[0].Address, "nats.internal:4222"},
+		{"telemetry.log_field_map.msg", cfg.Telemetry.LogFieldMap["msg"], "message"},
+		// Bare $ is kept and unset variables expand to nothing
+		{"bootstrap.postgres.password", cfg.Bootstrap.Postgres.Password, "pa$$word"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.field, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// reloadableFields are the settings a reload applies at runtime. Changes to
+// any other field are logged and ignored until the next restart.
+var reloadableFields = map[string]func(dst, src *Config){
+	"telemetry.log_level": func(dst, src *Config) {
+		dst.Telemetry.LogLevel = src.Telemetry.LogLevel
+	},
+	// Includes the per-dependency probe timeouts
+	"bootstrap.dependencies": func(dst, src *Config) {
+		dst.Bootstrap.Dependencies = src.Bootstrap.Dependencies
+	},
+	"health.check_timeout": func(dst, src *Config) {
+		dst.Health.CheckTimeout = src.Health.CheckTimeout
+	},
+}
+
+// Reloader re-reads the config file on demand or on SIGHUP and hands the
+// reloadable settings to the registered callbacks.
+type Reloader struct {
+	path   string
+	opts   LoadOptions
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	current  *Config
+	onReload []func(*Config)
+}
+
+// NewReloader creates a reloader for the file cfg was loaded from, with the
+// same options.
+func NewReloader(path string, opts LoadOptions, cfg *Config, logger *slog.Logger) *Reloader {
+	return &Reloader{
+		path:    path,
+		opts:    opts,
+		logger:  logger,
+		current: cfg,
+	}
+}
+
+// OnReload registers fn to be called with the updated config after a reload
+// that changed a reloadable setting. It must be called before WatchSignals.
+func (r *Reloader) OnReload(fn func(*Config)) {
+	r.onReload = append(r.onReload, fn)
+}
+
+// Current returns the config with every reload applied so far.
+func (r *Reloader) Current() *Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// Reload re-reads the config file. Changes to reloadable settings are applied
+// and passed to the OnReload callbacks; changes to anything else are logged
+// as ignored, as are bootstrap.dependencies while health.discovery.source
+// is consul. A file that fails to load or validate leaves the current config
+// in place.
+func (r *Reloader) Reload() error {
+	loaded, err := LoadWithOptions(r.path, r.opts)
+	if err != nil {
+		r.logger.Error("config reload failed, keeping current config", "path", r.path, "error", err)
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var applied, ignored []string
+	next := *r.current
+	for _, field := range diffFields("", reflect.ValueOf(*r.current), reflect.ValueOf(*loaded)) {
+		// A watch source owns the dependency list; applying the file's list
+		// would replace what it discovered until its next poll
+		if field == "bootstrap.dependencies" && r.current.Health.Discovery.Source == "consul" {
+			r.logger.Warn("bootstrap.dependencies changed but dependencies are discovered through consul, ignoring",
+				"path", r.path)
+			continue
+		}
+		if apply, ok := reloadableFields[field]; ok {
+			apply(&next, loaded)
+			applied = append(applied, field)
+		} else {
+			ignored = append(ignored, field)
+		}
+	}
+
+	if len(ignored) > 0 {
+		r.logger.Warn("config changes need a restart and were ignored", "fields", ignored)
+	}
+	if len(applied) == 0 {
+		r.logger.Info("config reloaded, nothing to apply", "path", r.path)
+		return nil
+	}
+
+	r.current = &next
+	for _, fn := range r.onReload {
+		fn(&next)
+	}
+	r.logger.Info("config reloaded", "path", r.path, "applied", applied)
+	return nil
+}
+
+// WatchSignals reloads the config on every SIGHUP until ctx is canceled.
+func (r *Reloader) WatchSignals(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			r.logger.Info("received SIGHUP, reloading config", "path", r.path)
+			r.Reload()
+		}
+	}
+}
+
+// diffFields returns the mapstructure paths of the fields that differ
+// between old and new. Structs are compared field by field; anything else,
+// including slices and maps, is compared as a whole.
+func diffFields(prefix string, old, new reflect.Value) []string {
+	if old.Kind() != reflect.Struct {
+		if reflect.DeepEqual(old.Interface(), new.Interface()) {
+			return nil
+		}
+		return []string{prefix}
+	}
+
+	var fields []string
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		fields = append(fields, diffFields(name, old.Field(i), new.Field(i))...)
+	}
+	return fields
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReloadDependenciesWithDiscovery(t *testing.T) {
+	const consul = `health:
+  discovery:
+    source: consul
+    consul_address: http://arc-consul:8500
+    consul_tag: raymond-probe
+    interval: 30s
+`
+	tests := []struct {
+		name        string
+		extra       string
+		wantAddress string
+	}{
+		{"static", "", "arc-sonic:6379"},
+		// Consul owns the dependency list, so the file's list is ignored
+		{"consul", consul, "arc-flash:4222"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, "config.yaml", configFormats["yaml"]+tt.extra)
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			reloader := NewReloader(path, LoadOptions{}, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			var reloaded *Config
+			reloader.OnReload(func(cfg *Config) { reloaded = cfg })
+
+			// Change a dependency and the log level
+			changed := strings.Replace(configFormats["yaml"], "arc-flash:4222", "arc-sonic:6379", 1) + tt.extra + "telemetry:\n  log_level: debug\n"
+			if err := os.WriteFile(path, []byte(changed), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+			if err := reloader.Reload(); err != nil {
+				t.Fatalf("Reload: %v", err)
+			}
+
+			if reloaded == nil {
+				t.Fatal("OnReload callback not called")
+			}
+			if got := reloaded.Telemetry.LogLevel; got != "debug" {
+				t.Errorf("log level = %q, want %q", got, "debug")
+			}
+			if got := reloaded.Bootstrap.Dependencies[0].Address; got != tt.wantAddress {
+				t.Errorf("dependency address = %q, want %q", got, tt.wantAddress)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+)
+
+// infisicalScheme prefixes config values that are fetched from Infisical,
+// e.g. infisical:///postgres/PASSWORD.
+const infisicalScheme = "infisical://"
+
+// SecretResolver fetches the secret stored at path.
+type SecretResolver interface {
+	Resolve(ctx context.Context, path string) (string, error)
+}
+
+// InfisicalResolver reads secrets through the Infisical API. A path such as
+// /postgres/PASSWORD names the secret PASSWORD in the /postgres folder.
+type InfisicalResolver struct {
+	address     string
+	token       string
+	projectID   string
+	environment string
+	client      *http.Client
+}
+
+// NewInfisicalResolver creates a resolver for the Infisical instance at
+// address, authenticating with a machine identity or service token and
+// reading from the given project and environment (e.g. "prod").
+func NewInfisicalResolver(address, token, projectID, environment string) *InfisicalResolver {
+	return &InfisicalResolver{
+		address:     strings.TrimSuffix(address, "/"),
+		token:       token,
+		projectID:   projectID,
+		environment: environment,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewInfisicalResolverFromEnv creates a resolver from INFISICAL_URL,
+// INFISICAL_TOKEN, INFISICAL_PROJECT_ID and INFISICAL_ENVIRONMENT, or returns
+// nil when INFISICAL_URL is not set.
+func NewInfisicalResolverFromEnv() *InfisicalResolver {
+	address := os.Getenv("INFISICAL_URL")
+	if address == "" {
+		return nil
+	}
+	return NewInfisicalResolver(address,
+		os.Getenv("INFISICAL_TOKEN"),
+		os.Getenv("INFISICAL_PROJECT_ID"),
+		os.Getenv("INFISICAL_ENVIRONMENT"))
+}
+
+// Resolve fetches the value of the secret at secretPath.
+func (r *InfisicalResolver) Resolve(ctx context.Context, secretPath string) (string, error) {
+	folder, name := path.Split(path.Clean("/" + secretPath))
+	if name == "" {
+		return "", fmt.Errorf("infisical secret path %q has no secret name", secretPath)
+	}
+
+	query := url.Values{
+		"workspaceId": {r.projectID},
+		"environment": {r.environment},
+		"secretPath":  {folder},
+	}
+	endpoint := r.address + "/api/v3/secrets/raw/" + url.PathEscape(name) + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("create infisical request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+r.token)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("infisical request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("infisical secret %s: unexpected status code: %d", secretPath, resp.StatusCode)
+	}
+
+	var body struct {
+		Secret struct {
+			SecretValue string `json:"secretValue"`
+		} `json:"secret"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode infisical response: %w", err)
+	}
+	return body.Secret.SecretValue, nil
+}
+
+// resolveSecrets replaces every string field of cfg holding an infisical://
+// reference with the secret it points to. Errors name the field and the
+// reference, never a secret value.
+func resolveSecrets(ctx context.Context, cfg *Config, resolver SecretResolver) error {
+	return resolveValue(ctx, "", reflect.ValueOf(cfg).Elem(), resolver)
+}
+
+// resolveValue recursively resolves secret references in v, whose config key
+// is key.
+func resolveValue(ctx context.Context, key string, v reflect.Value, resolver SecretResolver) error {
+	switch v.Kind() {
+	case reflect.String:
+		ref, ok := strings.CutPrefix(v.String(), infisicalScheme)
+		if !ok || !v.CanSet() {
+			return nil
+		}
+		if resolver == nil {
+			return fmt.Errorf("%w: %s references %s%s but INFISICAL_URL is not set",
+				pkgerrors.ErrConfigInvalid, key, infisicalScheme, ref)
+		}
+		secret, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("%w: resolve secret for %s: %w", pkgerrors.ErrConfigInvalid, key, err)
+		}
+		v.SetString(secret)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("mapstructure"), ",")
+			if key != "" {
+				name = key + "." + name
+			}
+			if err := resolveValue(ctx, name, v.Field(i), resolver); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := resolveValue(ctx, key+"["+strconv.Itoa(i)+"]", v.Index(i), resolver); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
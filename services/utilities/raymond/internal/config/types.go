@@ -7,51 +7,161 @@ type Config struct {
 	Server    ServerConfig    `mapstructure:"server" validate:"required"`
 	Telemetry TelemetryConfig `mapstructure:"telemetry" validate:"required"`
 	Bootstrap BootstrapConfig `mapstructure:"bootstrap" validate:"required"`
+	Security  SecurityConfig  `mapstructure:"security"`
+	Health    HealthConfig    `mapstructure:"health"`
+}
+
+// HealthConfig contains dependency health checking configuration.
+type HealthConfig struct {
+	Discovery                     DiscoveryConfig `mapstructure:"discovery"`
+	Warmup                        time.Duration   `mapstructure:"warmup" validate:"min=0"`
+	CheckTimeout                  time.Duration   `mapstructure:"check_timeout" validate:"required"`
+	ReadinessDelay                time.Duration   `mapstructure:"readiness_delay" validate:"min=0"`
+	ProbeBatchSize                int             `mapstructure:"probe_batch_size" validate:"min=0"`
+	ProbeBatchInterval            time.Duration   `mapstructure:"probe_batch_interval" validate:"min=0"`
+	ReadyRequiresTelemetry        bool            `mapstructure:"ready_requires_telemetry"`
+	ReadinessRequiresCriticalDeps bool            `mapstructure:"readiness_requires_critical_deps"`
+}
+
+// DiscoveryConfig selects where the dependencies to probe come from.
+type DiscoveryConfig struct {
+	Source        string        `mapstructure:"source" validate:"required,oneof=static consul"`
+	ConsulAddress string        `mapstructure:"consul_address" validate:"required_if=Source consul,omitempty,url"`
+	ConsulTag     string        `mapstructure:"consul_tag" validate:"required_if=Source consul"`
+	Interval      time.Duration `mapstructure:"interval" validate:"required_if=Source consul"`
+}
+
+// SecurityConfig contains settings applied to all outbound connections.
+type SecurityConfig struct {
+	MinTLSVersion string `mapstructure:"min_tls_version" validate:"required,oneof=1.2 1.3"`
 }
 
 // ServerConfig contains HTTP server configuration.
 type ServerConfig struct {
-	Port            int           `mapstructure:"port" validate:"required,min=1024,max=65535"`
-	ReadTimeout     time.Duration `mapstructure:"read_timeout" validate:"required"`
-	WriteTimeout    time.Duration `mapstructure:"write_timeout" validate:"required"`
-	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout" validate:"required"`
-	EnablePprof     bool          `mapstructure:"enable_pprof"`
+	Port               int           `mapstructure:"port" validate:"required,min=1024,max=65535"`
+	ReadTimeout        time.Duration `mapstructure:"read_timeout" validate:"required"`
+	WriteTimeout       time.Duration `mapstructure:"write_timeout" validate:"required"`
+	MaxHeaderBytes     int           `mapstructure:"max_header_bytes" validate:"min=0"`
+	ShutdownTimeout    time.Duration `mapstructure:"shutdown_timeout" validate:"required"`
+	EnablePprof        bool          `mapstructure:"enable_pprof"`
+	GRPCHealthPort     int           `mapstructure:"grpc_health_port" validate:"omitempty,min=1024,max=65535"`
+	HealthPathPrefix   string        `mapstructure:"health_path_prefix" validate:"omitempty,startswith=/,endsnotwith=/"`
+	DeepHealthTTL      time.Duration `mapstructure:"deep_health_cache_ttl" validate:"min=0"`
+	EnableCompression  bool          `mapstructure:"enable_compression"`
+	AdminToken         string        `mapstructure:"admin_token"`
+	CompressionMinSize int           `mapstructure:"compression_min_size" validate:"min=0"`
 }
 
 // TelemetryConfig contains observability configuration.
 type TelemetryConfig struct {
-	OTLPEndpoint string `mapstructure:"otlp_endpoint" validate:"required"`
-	OTLPInsecure bool   `mapstructure:"otlp_insecure"`
-	ServiceName  string `mapstructure:"service_name" validate:"required"`
-	LogLevel     string `mapstructure:"log_level" validate:"required,oneof=debug info warn error"`
+	OTLPEndpoint     string            `mapstructure:"otlp_endpoint" validate:"required"`
+	TraceEndpoint    string            `mapstructure:"trace_endpoint"`
+	MetricEndpoint   string            `mapstructure:"metric_endpoint"`
+	LogEndpoint      string            `mapstructure:"log_endpoint"`
+	OTLPInsecure     bool              `mapstructure:"otlp_insecure"`
+	OTLPCAFile       string            `mapstructure:"otlp_ca_file" validate:"omitempty,file"`
+	OTLPClientCert   string            `mapstructure:"otlp_client_cert" validate:"required_with=OTLPClientKey"`
+	OTLPClientKey    string            `mapstructure:"otlp_client_key" validate:"required_with=OTLPClientCert"`
+	OTLPServerName   string            `mapstructure:"otlp_server_name"`
+	ServiceName      string            `mapstructure:"service_name" validate:"required"`
+	TraceServiceName string            `mapstructure:"trace_service_name"`
+	LogServiceName   string            `mapstructure:"log_service_name"`
+	LogLevel         string            `mapstructure:"log_level" validate:"required,oneof=debug info warn error"`
+	LogSampleRate    float64           `mapstructure:"log_sample_rate" validate:"min=0,max=1"`
+	HistogramType    string            `mapstructure:"histogram_type" validate:"required,oneof=explicit exponential"`
+	StartupSelftest  bool              `mapstructure:"startup_selftest"`
+	TraceExporter    string            `mapstructure:"trace_exporter" validate:"required,oneof=otlp jaeger"`
+	JaegerEndpoint   string            `mapstructure:"jaeger_endpoint" validate:"required_if=TraceExporter jaeger"`
+	TraceSampleRatio float64           `mapstructure:"trace_sample_ratio" validate:"min=0,max=1"`
+	EnableTraces     bool              `mapstructure:"enable_traces"`
+	EnableMetrics    bool              `mapstructure:"enable_metrics"`
+	EnableLogs       bool              `mapstructure:"enable_logs"`
+	EnablePrometheus bool              `mapstructure:"enable_prometheus"`
+	PrometheusPath   string            `mapstructure:"prometheus_path" validate:"required_if=EnablePrometheus true,omitempty,startswith=/"`
+	LogFieldMap      map[string]string `mapstructure:"log_field_map"`
+
+	// Span attribute limits; 0 keeps the SDK default
+	SpanAttributeCountLimit       int `mapstructure:"span_attribute_count_limit" validate:"min=0"`
+	SpanAttributeValueLengthLimit int `mapstructure:"span_attribute_value_length_limit" validate:"min=0"`
+
+	// Longer string values in exported log records are truncated; 0 = no limit
+	LogAttributeValueLengthLimit int `mapstructure:"log_attribute_value_length_limit" validate:"min=0"`
 }
 
+// DependencyMonitorInterval is how often the background monitor re-checks
+// dependencies once bootstrap is running.
+const DependencyMonitorInterval = 30 * time.Second
+
 // BootstrapConfig contains platform initialization configuration.
 type BootstrapConfig struct {
-	Timeout       time.Duration      `mapstructure:"timeout" validate:"required"`
-	RetryAttempts int                `mapstructure:"retry_attempts" validate:"required,min=1,max=10"`
-	RetryBackoff  time.Duration      `mapstructure:"retry_backoff" validate:"required"`
-	Dependencies  []DependencyConfig `mapstructure:"dependencies" validate:"required,dive"`
-	NATS          NATSConfig         `mapstructure:"nats" validate:"required"`
-	Pulsar        PulsarConfig       `mapstructure:"pulsar" validate:"required"`
-	Postgres      PostgresConfig     `mapstructure:"postgres"`
-	Redis         RedisConfig        `mapstructure:"redis"`
+	Timeout                   time.Duration      `mapstructure:"timeout" validate:"required"`
+	MaxTotalDuration          time.Duration      `mapstructure:"max_total_duration" validate:"min=0"`
+	RetryAttempts             int                `mapstructure:"retry_attempts" validate:"required,min=1,max=10"`
+	RetryBackoff              time.Duration      `mapstructure:"retry_backoff" validate:"required"`
+	StallThreshold            time.Duration      `mapstructure:"stall_threshold" validate:"min=0"`
+	FailOnCriticalTimeout     bool               `mapstructure:"fail_on_critical_timeout"`
+	FailOnCriticalUnreachable bool               `mapstructure:"fail_on_critical_unreachable"`
+	Dependencies              []DependencyConfig `mapstructure:"dependencies" validate:"required,dive"`
+	NATS                      NATSConfig         `mapstructure:"nats" validate:"required"`
+	Pulsar                    PulsarConfig       `mapstructure:"pulsar" validate:"required"`
+	Postgres                  PostgresConfig     `mapstructure:"postgres"`
+	Redis                     RedisConfig        `mapstructure:"redis"`
 }
 
 // DependencyConfig defines a service dependency to wait for.
 type DependencyConfig struct {
-	Name     string        `mapstructure:"name" validate:"required"`
-	Type     string        `mapstructure:"type" validate:"required,oneof=tcp http grpc"`
-	Address  string        `mapstructure:"address"`
-	URL      string        `mapstructure:"url"`
-	Critical bool          `mapstructure:"critical"`
-	Timeout  time.Duration `mapstructure:"timeout"`
+	Name               string              `mapstructure:"name" validate:"required"`
+	Type               string              `mapstructure:"type" validate:"required,oneof=tcp http grpc srv"`
+	Address            string              `mapstructure:"address"`
+	URL                string              `mapstructure:"url"`
+	Port               int                 `mapstructure:"port" validate:"omitempty,min=1,max=65535"`
+	Critical           bool                `mapstructure:"critical"`
+	Timeout            time.Duration       `mapstructure:"timeout"`
+	JSONPath           string              `mapstructure:"json_path" validate:"required_with=ExpectedValue"`
+	ExpectedValue      string              `mapstructure:"expected_value" validate:"required_with=JSONPath"`
+	SidecarReadyURL    string              `mapstructure:"sidecar_ready_url" validate:"omitempty,url"`
+	BreakerFailures    int                 `mapstructure:"breaker_failures" validate:"min=0"`
+	BreakerRecovery    time.Duration       `mapstructure:"breaker_recovery" validate:"required_with=BreakerFailures"`
+	ExpectStatus       []int               `mapstructure:"expect_status" validate:"dive,min=100,max=599"`
+	ExpectBodyContains string              `mapstructure:"expect_body_contains"`
+	GRPCService        string              `mapstructure:"grpc_service"`
+	GRPCTLS            bool                `mapstructure:"grpc_tls"`
+	SRVService         string              `mapstructure:"srv_service" validate:"required_if=Type srv"`
+	SRVProto           string              `mapstructure:"srv_proto" validate:"omitempty,oneof=tcp udp"`
+	Targets            []ProbeTargetConfig `mapstructure:"targets" validate:"dive"`
+	TargetsPerProbe    int                 `mapstructure:"targets_per_probe" validate:"min=0"`
+	DetailedTiming     bool                `mapstructure:"detailed_timing"`
+}
+
+// ProbeTargetConfig is one of several addresses (tcp, grpc) or URLs (http)
+// serving a dependency. Targets with a higher weight are picked more often
+// when only a subset is probed per cycle.
+type ProbeTargetConfig struct {
+	Address string `mapstructure:"address"`
+	URL     string `mapstructure:"url" validate:"omitempty,url"`
+	Weight  int    `mapstructure:"weight" validate:"min=0"`
 }
 
 // NATSConfig contains NATS JetStream initialization configuration.
 type NATSConfig struct {
-	URL     string         `mapstructure:"url" validate:"required"`
-	Streams []StreamConfig `mapstructure:"streams" validate:"dive"`
+	URL         string            `mapstructure:"url" validate:"required"`
+	Streams     []StreamConfig    `mapstructure:"streams" validate:"dive"`
+	Consumers   []ConsumerConfig  `mapstructure:"consumers" validate:"dive"`
+	KV          []KVConfig        `mapstructure:"kv" validate:"dive"`
+	ConsumerLag ConsumerLagConfig `mapstructure:"consumer_lag"`
+}
+
+// ConsumerLagConfig controls periodic reporting of JetStream consumer lag.
+type ConsumerLagConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Interval  time.Duration `mapstructure:"interval" validate:"required_if=Enabled true"`
+	Consumers []ConsumerRef `mapstructure:"consumers" validate:"dive"`
+}
+
+// ConsumerRef identifies a JetStream consumer on a stream.
+type ConsumerRef struct {
+	Stream   string `mapstructure:"stream" validate:"required"`
+	Consumer string `mapstructure:"consumer" validate:"required"`
 }
 
 // StreamConfig defines a NATS JetStream stream to create.
@@ -63,13 +173,43 @@ type StreamConfig struct {
 	Replicas  int           `mapstructure:"replicas" validate:"min=1,max=5"`
 }
 
+// ConsumerConfig defines a durable JetStream pull consumer on a stream.
+type ConsumerConfig struct {
+	Stream        string        `mapstructure:"stream" validate:"required"`
+	Name          string        `mapstructure:"name" validate:"required"`
+	FilterSubject string        `mapstructure:"filter_subject"`
+	AckPolicy     string        `mapstructure:"ack_policy" validate:"required,oneof=explicit all none"`
+	AckWait       time.Duration `mapstructure:"ack_wait" validate:"min=0"`
+	MaxDeliver    int           `mapstructure:"max_deliver" validate:"min=0"`
+}
+
+// KVConfig defines a JetStream key-value bucket. A TTL of 0 keeps entries
+// forever.
+type KVConfig struct {
+	Bucket   string        `mapstructure:"bucket" validate:"required"`
+	TTL      time.Duration `mapstructure:"ttl" validate:"min=0"`
+	History  int           `mapstructure:"history" validate:"min=0,max=64"`
+	Replicas int           `mapstructure:"replicas" validate:"min=0,max=5"`
+}
+
 // PulsarConfig contains Apache Pulsar initialization configuration.
+// AdminURL is the broker's admin REST API (http://host:8080), used to
+// provision topics. ServiceURL is the binary protocol endpoint
+// (pulsar://host:6650) used by producers and consumers.
 type PulsarConfig struct {
-	AdminURL   string        `mapstructure:"admin_url" validate:"required"`
-	ServiceURL string        `mapstructure:"service_url"`
-	Tenant     string        `mapstructure:"tenant" validate:"required"`
-	Namespaces []string      `mapstructure:"namespaces" validate:"min=1"`
-	Topics     []TopicConfig `mapstructure:"topics" validate:"dive"`
+	AdminURL       string               `mapstructure:"admin_url" validate:"required,url"`
+	ServiceURL     string               `mapstructure:"service_url"`
+	Tenant         string               `mapstructure:"tenant" validate:"required"`
+	Namespaces     []string             `mapstructure:"namespaces" validate:"min=1"`
+	Topics         []TopicConfig        `mapstructure:"topics" validate:"dive"`
+	AdminRateLimit AdminRateLimitConfig `mapstructure:"admin_rate_limit"`
+	FlushTimeout   time.Duration        `mapstructure:"flush_timeout" validate:"min=0"`
+}
+
+// AdminRateLimitConfig limits calls to the Pulsar admin API. Zero disables a limit.
+type AdminRateLimitConfig struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second" validate:"min=0"`
+	MaxConcurrent     int     `mapstructure:"max_concurrent" validate:"min=0"`
 }
 
 // TopicConfig defines a Pulsar topic to create.
@@ -80,14 +220,23 @@ type TopicConfig struct {
 
 // PostgresConfig contains database configuration.
 type PostgresConfig struct {
-	Host     string `mapstructure:"host" validate:"required"`
-	Port     int    `mapstructure:"port" validate:"required,min=1,max=65535"`
-	User     string `mapstructure:"user" validate:"required"`
-	Password string `mapstructure:"password" validate:"required"`
-	Database string `mapstructure:"database" validate:"required"`
-	SSLMode  string `mapstructure:"ssl_mode" validate:"required,oneof=disable require verify-ca verify-full"`
-	MaxConns int    `mapstructure:"max_conns" validate:"min=1,max=100"`
-	MinConns int    `mapstructure:"min_conns" validate:"min=0,max=10"`
+	Host       string           `mapstructure:"host" validate:"required"`
+	Port       int              `mapstructure:"port" validate:"required,min=1,max=65535"`
+	User       string           `mapstructure:"user" validate:"required"`
+	Password   string           `mapstructure:"password" validate:"required"`
+	Database   string           `mapstructure:"database" validate:"required"`
+	SSLMode    string           `mapstructure:"ssl_mode" validate:"required,oneof=disable require verify-ca verify-full"`
+	MaxConns   int              `mapstructure:"max_conns" validate:"min=1,max=100"`
+	MinConns   int              `mapstructure:"min_conns" validate:"min=0,max=10"`
+	WarmPool   bool             `mapstructure:"warm_pool"`
+	Migrations MigrationsConfig `mapstructure:"migrations"`
+}
+
+// MigrationsConfig controls applying SQL migrations at bootstrap.
+type MigrationsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Dir     string `mapstructure:"dir" validate:"required_if=Enabled true"`
+	DryRun  bool   `mapstructure:"dry_run"`
 }
 
 // RedisConfig contains Redis configuration.
@@ -96,4 +245,5 @@ type RedisConfig struct {
 	Port     int    `mapstructure:"port" validate:"required,min=1,max=65535"`
 	Password string `mapstructure:"password"`
 	DB       int    `mapstructure:"db" validate:"min=0,max=15"`
+	TLS      bool   `mapstructure:"tls"`
 }
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// secretFieldMarkers identify fields whose values must not appear in
+// validation errors.
+var secretFieldMarkers = []string{"password", "secret", "token", "key"}
+
+// validateConfig validates cfg and reports every failing field, one per line,
+// named by its config key (e.g. "bootstrap.nats.url is required").
+func validateConfig(cfg *Config) error {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	var lines []string
+	if err := validate.Struct(cfg); err != nil {
+		var verrs validator.ValidationErrors
+		if !errors.As(err, &verrs) {
+			return fmt.Errorf("%w: %w", pkgerrors.ErrConfigInvalid, err)
+		}
+		lines = formatValidationErrors(verrs)
+	}
+	lines = append(lines, crossFieldErrors(cfg)...)
+	if len(lines) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %d field(s) failed validation:\n%s",
+		pkgerrors.ErrConfigInvalid, len(lines), strings.Join(lines, "\n"))
+}
+
+// crossFieldErrors checks rules that span several fields, in the same format
+// as formatValidationErrors.
+func crossFieldErrors(cfg *Config) []string {
+	var lines []string
+
+	// Background loops beat once per cycle, so a threshold no longer than
+	// the slowest cycle would restart healthy loops forever
+	if stall := cfg.Bootstrap.StallThreshold; stall > 0 {
+		cycle := DependencyMonitorInterval
+		if lag := cfg.Bootstrap.NATS.ConsumerLag; lag.Enabled && lag.Interval > cycle {
+			cycle = lag.Interval
+		}
+		if stall <= cycle {
+			lines = append(lines, fmt.Sprintf(
+				"  - bootstrap.stall_threshold must be longer than the %s background loop interval (got %s)",
+				cycle, stall))
+		}
+	}
+	return lines
+}
+
+// formatValidationErrors renders each field error as
+// "  - <field> <message> (got <value>)".
+func formatValidationErrors(verrs validator.ValidationErrors) []string {
+	lines := make([]string, 0, len(verrs))
+	for _, fe := range verrs {
+		line := fmt.Sprintf("  - %s %s", fieldPath(fe), ruleMessage(fe))
+		// A missing value is self-explanatory
+		if !strings.HasPrefix(fe.Tag(), "required") {
+			line += fmt.Sprintf(" (got %s)", fieldValue(fe))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// ruleMessage describes the rule a field broke, e.g. "must be at least 1".
+func ruleMessage(fe validator.FieldError) string {
+	param := fe.Param()
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_if":
+		field, value, _ := strings.Cut(param, " ")
+		return fmt.Sprintf("is required when %s is %s", siblingKey(fe, field), value)
+	case "required_with":
+		fields := strings.Fields(param)
+		for i, field := range fields {
+			fields[i] = siblingKey(fe, field)
+		}
+		return fmt.Sprintf("is required when %s is set", strings.Join(fields, " or "))
+	case "min":
+		return "must be at least " + param + sizeUnit(fe.Kind())
+	case "max":
+		return "must be at most " + param + sizeUnit(fe.Kind())
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "url":
+		return "must be a valid URL"
+	case "file":
+		return "must be an existing file"
+	case "startswith":
+		return fmt.Sprintf("must start with %q", param)
+	case "endsnotwith":
+		return fmt.Sprintf("must not end with %q", param)
+	}
+	if param != "" {
+		return fmt.Sprintf("failed %q", fe.Tag()+"="+param)
+	}
+	return fmt.Sprintf("failed %q", fe.Tag())
+}
+
+// sizeUnit is what min and max count for kinds that aren't numbers.
+func sizeUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters long"
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return " items"
+	}
+	return ""
+}
+
+// fieldPath returns the field's path below the root Config struct.
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.IndexByte(ns, '.'); i >= 0 {
+		return ns[i+1:]
+	}
+	return ns
+}
+
+// siblingKey returns the config key of the field with Go name goName in the
+// same struct as fe's field, e.g. "health.discovery.source" for Source, since
+// rule parameters name fields by their Go names.
+func siblingKey(fe validator.FieldError, goName string) string {
+	parent := reflect.TypeOf(Config{})
+	segments := strings.Split(fe.StructNamespace(), ".")
+	for _, segment := range segments[1 : len(segments)-1] {
+		segment, _, _ = strings.Cut(segment, "[")
+		field, ok := parent.FieldByName(segment)
+		if !ok {
+			return goName
+		}
+		parent = field.Type
+		for parent.Kind() == reflect.Slice || parent.Kind() == reflect.Map || parent.Kind() == reflect.Pointer {
+			parent = parent.Elem()
+		}
+	}
+
+	field, ok := parent.FieldByName(goName)
+	if !ok {
+		return goName
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if name == "" {
+		return goName
+	}
+	path := fieldPath(fe)
+	if i := strings.LastIndexByte(path, '.'); i >= 0 {
+		return path[:i+1] + name
+	}
+	return name
+}
+
+// fieldValue formats the offending value, redacting secrets.
+func fieldValue(fe validator.FieldError) string {
+	name := strings.ToLower(fe.StructField())
+	for _, marker := range secretFieldMarkers {
+		if strings.Contains(name, marker) {
+			return "[redacted]"
+		}
+	}
+	if d, ok := fe.Value().(time.Duration); ok {
+		return d.String()
+	}
+	return fmt.Sprintf("%#v", fe.Value())
+}
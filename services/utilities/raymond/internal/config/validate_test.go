@@ -0,0 +1,120 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestLoadReportsEveryInvalidField(t *testing.T) {
+	// Port 80 is below the unprivileged range
+	path := writeConfig(t, "config.yaml", strings.Replace(configFormats["yaml"], "port: 9090", "port: 80", 1)+`telemetry:
+  log_level: verbose
+`)
+
+	_, err := Load(path)
+	if !errors.Is(err, pkgerrors.ErrConfigInvalid) {
+		t.Fatalf("Load error = %v, want %v", err, pkgerrors.ErrConfigInvalid)
+	}
+	for _, want := range []string{
+		"2 field(s) failed validation",
+		"  - server.port must be at least 1024 (got 80)",
+		`  - telemetry.log_level must be one of: debug, info, warn, error (got "verbose")`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not contain %q:\n%s", want, err)
+		}
+	}
+}
+
+func TestFieldValueRedactsSecrets(t *testing.T) {
+	type credentials struct {
+		User     string `validate:"min=8"`
+		Password string `validate:"min=12"`
+	}
+	err := validator.New().Struct(credentials{User: "arc", Password: "hunter2"})
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) || len(verrs) != 2 {
+		t.Fatalf("validation error = %v, want two field errors", err)
+	}
+	for _, fe := range verrs {
+		got := fieldValue(fe)
+		switch fe.StructField() {
+		case "User":
+			if got != `"arc"` {
+				t.Errorf("User value = %s, want %q", got, `"arc"`)
+			}
+		case "Password":
+			if got != "[redacted]" {
+				t.Errorf("Password value = %s, want [redacted]", got)
+			}
+		}
+	}
+}
+
+func TestValidateConfigMessages(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string
+	}{
+		{
+			"missing required field",
+			func(c *Config) { c.Bootstrap.NATS.URL = "" },
+			"  - bootstrap.nats.url is required",
+		},
+		{
+			"missing required field in list",
+			func(c *Config) { c.Bootstrap.Dependencies[0].Name = "" },
+			"  - bootstrap.dependencies[0].name is required",
+		},
+		{
+			"integer above max",
+			func(c *Config) { c.Bootstrap.RetryAttempts = 11 },
+			"  - bootstrap.retry_attempts must be at most 10 (got 11)",
+		},
+		{
+			"port out of range",
+			func(c *Config) { c.Server.Port = 70000 },
+			"  - server.port must be at most 65535 (got 70000)",
+		},
+		{
+			"ratio above max",
+			func(c *Config) { c.Telemetry.TraceSampleRatio = 1.5 },
+			"  - telemetry.trace_sample_ratio must be at most 1 (got 1.5)",
+		},
+		{
+			"required_if",
+			func(c *Config) { c.Telemetry.TraceExporter, c.Telemetry.JaegerEndpoint = "jaeger", "" },
+			"  - telemetry.jaeger_endpoint is required when telemetry.trace_exporter is jaeger",
+		},
+		{
+			"required_with",
+			func(c *Config) { c.Telemetry.OTLPClientCert = "/etc/raymond/client.pem" },
+			"  - telemetry.otlp_client_key is required when telemetry.otlp_client_cert is set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, "config.yaml", configFormats["yaml"]))
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			tt.modify(cfg)
+
+			err = validateConfig(cfg)
+			if !errors.Is(err, pkgerrors.ErrConfigInvalid) {
+				t.Fatalf("validateConfig error = %v, want %v", err, pkgerrors.ErrConfigInvalid)
+			}
+			want := pkgerrors.ErrConfigInvalid.Error() + ": 1 field(s) failed validation:\n" + tt.want
+			if err.Error() != want {
+				t.Errorf("validateConfig error =\n%s\nwant\n%s", err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// resultCache serves recent RunAll results so frequent deep health polling
+// doesn't re-probe every dependency on each request. Concurrent misses share
+// one probe round, even with caching disabled, so a burst of requests never
+// multiplies the probe connections.
+type resultCache struct {
+	checker *Checker
+	ttl     time.Duration
+	group   singleflight.Group
+
+	mu      sync.Mutex
+	results map[string]ProbeResult
+	at      time.Time
+}
+
+// newResultCache creates a cache holding results for ttl. A ttl of 0 disables
+// caching; concurrent calls still share a probe round.
+func newResultCache(checker *Checker, ttl time.Duration) *resultCache {
+	return &resultCache{checker: checker, ttl: ttl}
+}
+
+// RunAll returns the cached results if they are younger than the TTL and
+// otherwise runs a new probe round, shared with any concurrent callers.
+func (rc *resultCache) RunAll(ctx context.Context) map[string]ProbeResult {
+	if results, ok := rc.fresh(); ok {
+		return results
+	}
+
+	v, _, _ := rc.group.Do("run-all", func() (interface{}, error) {
+		if results, ok := rc.fresh(); ok {
+			return results, nil
+		}
+
+		// The round is shared, so one caller going away must not cancel it;
+		// each probe is still bounded by its own timeout
+		results := rc.checker.RunAll(context.WithoutCancel(ctx))
+		if rc.ttl <= 0 {
+			return results, nil
+		}
+
+		rc.mu.Lock()
+		rc.results = results
+		rc.at = time.Now()
+		rc.mu.Unlock()
+		return results, nil
+	})
+	return v.(map[string]ProbeResult)
+}
+
+// fresh returns the cached results if they are within the TTL.
+func (rc *resultCache) fresh() (map[string]ProbeResult, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.ttl <= 0 || rc.results == nil || time.Since(rc.at) >= rc.ttl {
+		return nil, false
+	}
+	return rc.results, true
+}
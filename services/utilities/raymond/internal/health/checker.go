@@ -1,41 +1,224 @@
 package health
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net"
 	"net/http"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/telemetry"
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
+// Probe statuses reported in ProbeResult.Status.
+const (
+	ProbeStatusHealthy         = "healthy"
+	ProbeStatusUnhealthy       = "unhealthy"
+	ProbeStatusSidecarNotReady = "sidecar_not_ready"
+	ProbeStatusInitializing    = "initializing"
+	ProbeStatusProbeError      = "probe_error"
+	ProbeStatusDegraded        = "degraded" // some, but not all, probed targets failed
+)
+
+// errSidecarNotReady marks probe failures caused by the local mesh sidecar
+// rather than the dependency itself.
+var errSidecarNotReady = errors.New("sidecar not ready")
+
+// isLocalProbeFailure reports whether err means the probe itself could not
+// run, e.g. because this process ran out of file descriptors or ports, rather
+// than the dependency being unreachable.
+func isLocalProbeFailure(err error) bool {
+	return errors.Is(err, syscall.EMFILE) ||
+		errors.Is(err, syscall.ENFILE) ||
+		errors.Is(err, syscall.ENOBUFS) ||
+		errors.Is(err, syscall.ENOMEM) ||
+		errors.Is(err, syscall.EADDRNOTAVAIL)
+}
+
 // ProbeResult contains the result of a health probe.
 type ProbeResult struct {
 	Name      string
+	Type      string
+	Target    string
 	OK        bool
+	Status    string
 	LatencyMS int64
 	Error     string
+	Stale     bool
+	Targets   []TargetResult `json:",omitempty"` // per-target detail for multi-target dependencies
+	Timing    *HTTPTiming    `json:",omitempty"` // HTTP phase timings with detailed_timing
 }
 
 // Checker orchestrates health checks for all dependencies.
 type Checker struct {
+	mu           sync.RWMutex
 	dependencies []config.DependencyConfig
+	depContexts  map[string]context.Context
+	depCancels   map[string]context.CancelFunc
+	historyMu    sync.Mutex
+	history      map[string]*resultHistory
+	lastResults  map[string]ProbeResult
+	monitored    Snapshot
+	breakers     map[string]*gobreaker.CircuitBreaker
 	logger       *slog.Logger
-	timeout      time.Duration
+	metrics      *telemetry.Metrics
+	tracer       trace.Tracer
+	httpClient   *http.Client
+	tlsCfg       *tls.Config
+	resolver     srvResolver
+	timeout      atomic.Int64 // time.Duration
+	startedAt    time.Time
+	warmup       atomic.Int64 // time.Duration
+	failCritical atomic.Bool
+
+	// Probes are dispatched batchSize at a time, batchInterval apart; a
+	// batchSize of 0 dispatches them all at once
+	batchSize     int
+	batchInterval time.Duration
 }
 
-// NewChecker creates a new health checker.
-func NewChecker(deps []config.DependencyConfig, logger *slog.Logger, timeout time.Duration) *Checker {
-	return &Checker{
-		dependencies: deps,
-		logger:       logger,
-		timeout:      timeout,
+// NewChecker creates a new health checker. tlsCfg is the base TLS
+// configuration for HTTPS and TLS-enabled gRPC probes.
+func NewChecker(deps []config.DependencyConfig, logger *slog.Logger, tlsCfg *tls.Config, timeout time.Duration) *Checker {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	c := &Checker{
+		depContexts: make(map[string]context.Context),
+		depCancels:  make(map[string]context.CancelFunc),
+		history:     make(map[string]*resultHistory),
+		lastResults: make(map[string]ProbeResult),
+		breakers:    make(map[string]*gobreaker.CircuitBreaker),
+		logger:      logger,
+		tracer:      otel.Tracer("github.com/arc-framework/platform-spike/services/raymond/internal/health"),
+		httpClient:  &http.Client{Transport: otelhttp.NewTransport(transport)},
+		tlsCfg:      tlsCfg,
+		resolver:    net.DefaultResolver,
+		startedAt:   time.Now(),
 	}
+	c.SetTimeout(timeout)
+	c.SetDependencies(deps)
+	return c
+}
+
+// Monitored returns the snapshot of the latest background monitor results.
+func (c *Checker) Monitored() *Snapshot {
+	return &c.monitored
+}
+
+// SetMetrics records probe latencies to metrics, which are otherwise not
+// recorded. It must be called before probing starts.
+func (c *Checker) SetMetrics(metrics *telemetry.Metrics) {
+	c.metrics = metrics
+}
+
+// SetTracer sets the tracer for probe spans, which otherwise come from the
+// global tracer provider. It must be called before probing starts.
+func (c *Checker) SetTracer(tracer trace.Tracer) {
+	c.tracer = tracer
+}
+
+// SetTimeout sets the probe timeout for dependencies that don't set their
+// own. It takes effect from the next probe, e.g. after a config reload.
+func (c *Checker) SetTimeout(d time.Duration) {
+	c.timeout.Store(int64(d))
+}
+
+// SetWarmup sets how long after the checker is created probe failures are
+// reported as initializing rather than unhealthy, to ride out dependencies
+// that are still starting.
+func (c *Checker) SetWarmup(d time.Duration) {
+	c.warmup.Store(int64(d))
+}
+
+// SetProbeBatching staggers each RunAll cycle into batches of size probes,
+// started interval apart, to avoid a burst of simultaneous connections. A
+// size of 0 disables batching.
+func (c *Checker) SetProbeBatching(size int, interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.batchSize = size
+	c.batchInterval = interval
+}
+
+// SetFailOnCriticalTimeout makes WaitForDependencies return an error when a
+// critical dependency is still unhealthy once the wait ends, instead of
+// continuing degraded.
+func (c *Checker) SetFailOnCriticalTimeout(fail bool) {
+	c.failCritical.Store(fail)
+}
+
+// warmingUp reports whether the checker is still within its warmup window.
+func (c *Checker) warmingUp() bool {
+	return time.Since(c.startedAt) < time.Duration(c.warmup.Load())
+}
+
+// SetDependencies replaces the set of dependencies to probe. In-flight probes
+// for dependencies that are no longer present are canceled and their results
+// discarded.
+func (c *Checker) SetDependencies(deps []config.DependencyConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keep := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		keep[dep.Name] = true
+		if _, ok := c.depContexts[dep.Name]; !ok {
+			ctx, cancel := context.WithCancel(context.Background())
+			c.depContexts[dep.Name] = ctx
+			c.depCancels[dep.Name] = cancel
+		}
+		if _, ok := c.breakers[dep.Name]; !ok && dep.BreakerFailures > 0 {
+			c.breakers[dep.Name] = newProbeBreaker(dep)
+		}
+	}
+
+	for name, cancel := range c.depCancels {
+		if keep[name] {
+			continue
+		}
+		cancel()
+		delete(c.depCancels, name)
+		delete(c.depContexts, name)
+		delete(c.breakers, name)
+		c.historyMu.Lock()
+		delete(c.history, name)
+		delete(c.lastResults, name)
+		c.historyMu.Unlock()
+		c.logger.Info("dependency removed, canceling in-flight probes", "service", name)
+	}
+
+	c.dependencies = deps
+}
+
+// Dependencies returns the current set of dependencies.
+func (c *Checker) Dependencies() []config.DependencyConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dependencies
 }
 
 // RunAll executes all health probes concurrently and returns results.
@@ -43,16 +226,52 @@ func (c *Checker) RunAll(ctx context.Context) map[string]ProbeResult {
 	results := make(map[string]ProbeResult)
 	var mu sync.Mutex
 
+	c.mu.RLock()
+	deps := c.dependencies
+	batchSize, batchInterval := c.batchSize, c.batchInterval
+	depContexts := make(map[string]context.Context, len(deps))
+	breakers := make(map[string]*gobreaker.CircuitBreaker, len(c.breakers))
+	for _, dep := range deps {
+		depContexts[dep.Name] = c.depContexts[dep.Name]
+		if b, ok := c.breakers[dep.Name]; ok {
+			breakers[dep.Name] = b
+		}
+	}
+	c.mu.RUnlock()
+
 	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(10) // Limit concurrent probes
 
-	for _, dep := range c.dependencies {
+dispatch:
+	for i, dep := range deps {
+		if batchSize > 0 && i > 0 && i%batchSize == 0 {
+			select {
+			case <-gctx.Done():
+				break dispatch
+			case <-time.After(batchInterval):
+			}
+		}
+
 		dep := dep // Capture loop variable
+		depCtx := depContexts[dep.Name]
 		g.Go(func() error {
-			result := c.runProbe(gctx, dep)
+			// Cancel the probe if the dependency is removed while it runs
+			probeCtx, cancel := context.WithCancel(gctx)
+			defer cancel()
+			stop := context.AfterFunc(depCtx, cancel)
+			defer stop()
+
+			result := c.runProbe(probeCtx, dep, breakers[dep.Name])
+			if depCtx.Err() != nil {
+				return nil // Dependency removed mid-probe; drop the stale result
+			}
+
 			mu.Lock()
 			results[dep.Name] = result
 			mu.Unlock()
+			if result.Status != ProbeStatusProbeError {
+				c.recordHistory(result)
+			}
 			return nil
 		})
 	}
@@ -61,9 +280,81 @@ func (c *Checker) RunAll(ctx context.Context) map[string]ProbeResult {
 	return results
 }
 
+// recordHistory appends a probe outcome to the dependency's result history
+// and remembers it as the last known result.
+func (c *Checker) recordHistory(result ProbeResult) {
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+
+	h, exists := c.history[result.Name]
+	if !exists {
+		h = &resultHistory{}
+		c.history[result.Name] = h
+	}
+	h.add(result.OK)
+	c.lastResults[result.Name] = result
+}
+
+// withStaleFallback replaces results whose probe could not run because of a
+// local failure with the dependency's last known result, marked stale. Such
+// failures say nothing about the dependency itself.
+func (c *Checker) withStaleFallback(results map[string]ProbeResult) map[string]ProbeResult {
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+
+	// results may be shared through the deep health cache, so build a copy
+	merged := make(map[string]ProbeResult, len(results))
+	for name, result := range results {
+		if result.Status == ProbeStatusProbeError {
+			if last, ok := c.lastResults[name]; ok {
+				last.Stale = true
+				result = last
+			}
+		}
+		merged[name] = result
+	}
+	return merged
+}
+
+// FailingCritical returns the critical dependencies whose latest probe result
+// is not healthy, or that have not been probed yet, sorted by name.
+func (c *Checker) FailingCritical() []string {
+	deps := c.Dependencies()
+
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+
+	var failing []string
+	for _, dep := range deps {
+		if !dep.Critical {
+			continue
+		}
+		if last, ok := c.lastResults[dep.Name]; !ok || !last.OK {
+			failing = append(failing, dep.Name)
+		}
+	}
+	slices.Sort(failing)
+	return failing
+}
+
+// FlapCount returns the number of healthy/unhealthy transitions in the
+// dependency's recent probe history.
+func (c *Checker) FlapCount(name string) int {
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+
+	h, exists := c.history[name]
+	if !exists {
+		return 0
+	}
+	return h.flaps()
+}
+
 // WaitForDependencies waits for all critical dependencies to become healthy.
-// Returns when all critical deps are ready OR when maxWait duration is reached.
-// This is non-blocking and will return with current status after timeout.
+// Returns when all critical deps are ready OR when maxWait duration is reached;
+// a deadline on ctx ends the wait the same way. This is non-blocking and will
+// return with current status after timeout, unless fail on critical timeout
+// is set and a critical dependency is still unhealthy.
 func (c *Checker) WaitForDependencies(ctx context.Context) error {
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
@@ -77,6 +368,11 @@ func (c *Checker) WaitForDependencies(ctx context.Context) error {
 			// Context canceled - return current status instead of error
 			results := c.RunAll(context.Background())
 			c.logDependencyStatus(results)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				if err := c.criticalFailure(results); err != nil {
+					return err
+				}
+			}
 			c.logger.Warn("dependency wait interrupted, continuing with current status")
 			return nil // Don't fail, just continue
 
@@ -85,6 +381,9 @@ func (c *Checker) WaitForDependencies(ctx context.Context) error {
 				// Timeout reached - log status and continue
 				results := c.RunAll(context.Background())
 				c.logDependencyStatus(results)
+				if err := c.criticalFailure(results); err != nil {
+					return err
+				}
 				c.logger.Warn("dependency wait timeout reached, continuing anyway",
 					"max_wait", maxWait.String())
 				return nil // Don't fail, just continue
@@ -94,7 +393,7 @@ func (c *Checker) WaitForDependencies(ctx context.Context) error {
 			allHealthy := true
 			unhealthyCount := 0
 
-			for _, dep := range c.dependencies {
+			for _, dep := range c.Dependencies() {
 				if !dep.Critical {
 					continue
 				}
@@ -119,6 +418,26 @@ func (c *Checker) WaitForDependencies(ctx context.Context) error {
 	}
 }
 
+// criticalFailure returns an ErrDependencyUnhealthy error naming each
+// critical dependency that is not healthy in results, or nil when fail on
+// critical timeout is disabled or all critical dependencies are healthy.
+func (c *Checker) criticalFailure(results map[string]ProbeResult) error {
+	if !c.failCritical.Load() {
+		return nil
+	}
+
+	var errs []error
+	for _, dep := range c.Dependencies() {
+		if dep.Critical && !results[dep.Name].OK {
+			errs = append(errs, pkgerrors.NewDependencyError(dep.Name, pkgerrors.ErrDependencyUnhealthy))
+		}
+	}
+	if len(errs) > 0 {
+		c.logger.Error("critical dependencies still unhealthy, failing", "count", len(errs))
+	}
+	return errors.Join(errs...)
+}
+
 // logDependencyStatus logs the current status of all dependencies.
 func (c *Checker) logDependencyStatus(results map[string]ProbeResult) {
 	for name, result := range results {
@@ -130,49 +449,144 @@ func (c *Checker) logDependencyStatus(results map[string]ProbeResult) {
 	}
 }
 
-// runProbe executes a single health probe based on dependency type.
-func (c *Checker) runProbe(ctx context.Context, dep config.DependencyConfig) ProbeResult {
+// runProbe executes a single health probe based on dependency type. When the
+// dependency has a probe breaker that is open, the probe is skipped and
+// reported as failed until the breaker's recovery window elapses. Dependencies
+// with several targets are reported unhealthy if any probed target fails, or
+// degraded if some of them are still healthy.
+func (c *Checker) runProbe(ctx context.Context, dep config.DependencyConfig, breaker *gobreaker.CircuitBreaker) ProbeResult {
 	timeout := dep.Timeout
 	if timeout == 0 {
-		timeout = c.timeout
+		timeout = time.Duration(c.timeout.Load())
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	// The probe latency is recorded under this span, so the histogram
+	// carries its trace ID as an exemplar
+	ctx, span := c.tracer.Start(ctx, "health.probe", trace.WithAttributes(
+		attribute.String("dependency.name", dep.Name),
+		attribute.String("dependency.type", dep.Type),
+	))
+	defer span.End()
+
+	var timing *timingRecorder
+	if dep.DetailedTiming && dep.Type == "http" && len(dep.Targets) == 0 {
+		timing = &timingRecorder{}
+		ctx = withTimingRecorder(ctx, timing)
+	}
+
 	start := time.Now()
 	var err error
+	var targets []TargetResult
+	probe := func() error {
+		if len(dep.Targets) == 0 {
+			return c.probe(ctx, dep)
+		}
+		var err error
+		targets, err = c.probeTargets(ctx, dep)
+		return err
+	}
 
-	switch dep.Type {
-	case "tcp":
-		err = c.probeTCP(ctx, dep.Address)
-	case "http":
-		err = c.probeHTTP(ctx, dep.URL)
-	case "grpc":
-		err = c.probeGRPC(ctx, dep.Address)
-	default:
-		err = fmt.Errorf("unknown probe type: %s", dep.Type)
+	if breaker != nil {
+		_, err = breaker.Execute(func() (interface{}, error) {
+			return nil, probe()
+		})
+	} else {
+		err = probe()
 	}
 
-	latency := time.Since(start).Milliseconds()
+	target := probeTarget(dep)
+	if len(targets) > 0 {
+		target = targetsLabel(targets)
+	}
+	var httpTiming *HTTPTiming
+	if timing != nil {
+		httpTiming = timing.result()
+	}
+
+	elapsed := time.Since(start)
+	latency := elapsed.Milliseconds()
+
+	if c.metrics != nil {
+		c.metrics.RecordProbe(ctx, dep.Name, elapsed.Seconds())
+	}
 
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		status := ProbeStatusUnhealthy
+		switch {
+		case isLocalProbeFailure(err):
+			status = ProbeStatusProbeError
+		case c.warmingUp():
+			status = ProbeStatusInitializing
+		case errors.Is(err, errSidecarNotReady):
+			status = ProbeStatusSidecarNotReady
+		case anyTargetOK(targets):
+			status = ProbeStatusDegraded
+		}
 		return ProbeResult{
 			Name:      dep.Name,
+			Type:      dep.Type,
+			Target:    target,
 			OK:        false,
+			Status:    status,
 			LatencyMS: latency,
 			Error:     err.Error(),
+			Targets:   targets,
+			Timing:    httpTiming,
 		}
 	}
 
 	return ProbeResult{
 		Name:      dep.Name,
+		Type:      dep.Type,
+		Target:    target,
 		OK:        true,
+		Status:    ProbeStatusHealthy,
 		LatencyMS: latency,
 		Error:     "",
+		Targets:   targets,
+		Timing:    httpTiming,
 	}
 }
 
+// probe runs the check for dep's probe type.
+func (c *Checker) probe(ctx context.Context, dep config.DependencyConfig) error {
+	switch dep.Type {
+	case "tcp":
+		return c.probeTCP(ctx, dialTarget(dep))
+	case "http":
+		return c.probeHTTP(ctx, dep)
+	case "grpc":
+		return c.probeGRPC(ctx, dep)
+	case "srv":
+		return c.probeSRV(ctx, dep)
+	default:
+		return fmt.Errorf("unknown probe type: %s", dep.Type)
+	}
+}
+
+// newProbeBreaker creates a breaker that stops probing dep after
+// BreakerFailures consecutive failures and tries again once BreakerRecovery
+// has elapsed. Local probe failures don't count against the dependency.
+func newProbeBreaker(dep config.DependencyConfig) *gobreaker.CircuitBreaker {
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        "probe-" + dep.Name,
+		MaxRequests: 1,
+		Timeout:     dep.BreakerRecovery,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= uint32(dep.BreakerFailures)
+		},
+		IsSuccessful: func(err error) bool {
+			return err == nil || isLocalProbeFailure(err)
+		},
+	})
+}
+
 // probeTCP performs a TCP dial check.
 func (c *Checker) probeTCP(ctx context.Context, address string) error {
 	var d net.Dialer
@@ -184,14 +598,94 @@ func (c *Checker) probeTCP(ctx context.Context, address string) error {
 	return nil
 }
 
-// probeHTTP performs an HTTP GET request check.
-func (c *Checker) probeHTTP(ctx context.Context, url string) error {
+// probeHTTP performs an HTTP GET request check. The status code must be one
+// of the dependency's expected statuses, or any 2xx when none are set. When
+// the dependency sets a JSON path, the value at that path in the response body
+// must also match the expected value, and when it sets expect_body_contains
+// the body must contain that text. When it sets a sidecar readiness URL, the sidecar is checked
+// first so mesh problems are not reported as dependency failures.
+func (c *Checker) probeHTTP(ctx context.Context, dep config.DependencyConfig) error {
+	if dep.SidecarReadyURL != "" {
+		if err := c.probeSidecar(ctx, dep.SidecarReadyURL); err != nil {
+			return fmt.Errorf("%w: %w", errSidecarNotReady, err)
+		}
+	}
+
+	target, err := httpTarget(dep)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(traceTiming(ctx), http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("http request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !expectedStatus(dep.ExpectStatus, resp.StatusCode) {
+		io.Copy(io.Discard, resp.Body)
+		if len(dep.ExpectStatus) > 0 {
+			return fmt.Errorf("unexpected status code: %d, expected one of %v", resp.StatusCode, dep.ExpectStatus)
+		}
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if dep.JSONPath == "" && dep.ExpectBodyContains == "" {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	limit := int64(maxBodyMatchBytes)
+	if dep.JSONPath != "" {
+		limit = maxProbeBodyBytes
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if dep.ExpectBodyContains != "" && !bytes.Contains(body, []byte(dep.ExpectBodyContains)) {
+		return fmt.Errorf("response body does not contain %q", dep.ExpectBodyContains)
+	}
+
+	if dep.JSONPath == "" {
+		return nil
+	}
+
+	value, err := lookupJSONPath(body, dep.JSONPath)
+	if err != nil {
+		return err
+	}
+	if value != dep.ExpectedValue {
+		return fmt.Errorf("%s is %q, expected %q", dep.JSONPath, value, dep.ExpectedValue)
+	}
+
+	return nil
+}
+
+// expectedStatus reports whether code is in expected, or is a 2xx when
+// expected is empty.
+func expectedStatus(expected []int, code int) bool {
+	if len(expected) == 0 {
+		return code >= 200 && code < 300
+	}
+	return slices.Contains(expected, code)
+}
+
+// probeSidecar checks a local sidecar readiness endpoint such as Envoy's
+// admin /ready.
+func (c *Checker) probeSidecar(ctx context.Context, url string) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("http request failed: %w", err)
 	}
@@ -201,12 +695,36 @@ func (c *Checker) probeHTTP(ctx context.Context, url string) error {
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
-
 	return nil
 }
 
-// probeGRPC performs a gRPC health check (simplified).
-func (c *Checker) probeGRPC(ctx context.Context, address string) error {
-	// For now, use TCP check. In production, implement grpc.health.v1.Health service
-	return c.probeTCP(ctx, address)
+// probeGRPC calls the standard grpc.health.v1.Health/Check RPC, for the
+// dependency's GRPCService if set or the whole server otherwise, and succeeds
+// only when the reported status is SERVING.
+func (c *Checker) probeGRPC(ctx context.Context, dep config.DependencyConfig) error {
+	creds := insecure.NewCredentials()
+	if dep.GRPCTLS {
+		creds = credentials.NewTLS(c.tlsCfg.Clone())
+	}
+
+	conn, err := grpc.NewClient(dialTarget(dep), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return fmt.Errorf("create grpc client: %w", err)
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: dep.GRPCService,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			return fmt.Errorf("grpc health check failed: %s: %s", st.Code(), st.Message())
+		}
+		return fmt.Errorf("grpc health check failed: %w", err)
+	}
+
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("grpc health status: %s", resp.GetStatus())
+	}
+	return nil
 }
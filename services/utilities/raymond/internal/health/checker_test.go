@@ -0,0 +1,387 @@
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/telemetry"
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// discardLogger returns a logger that drops everything.
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// newHTTPDependency serves handler and returns a dependency probing it.
+func newHTTPDependency(t *testing.T, name string, handler http.HandlerFunc) config.DependencyConfig {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return config.DependencyConfig{Name: name, Type: "http", URL: srv.URL}
+}
+
+func TestRunAllDropsResultOfRemovedDependency(t *testing.T) {
+	started := make(chan struct{})
+	var once sync.Once
+	slow := newHTTPDependency(t, "slow", func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() { close(started) })
+		<-r.Context().Done()
+	})
+	fast := newHTTPDependency(t, "fast", func(w http.ResponseWriter, r *http.Request) {})
+
+	c := NewChecker([]config.DependencyConfig{slow, fast}, discardLogger(), nil, 5*time.Second)
+
+	done := make(chan map[string]ProbeResult, 1)
+	go func() { done <- c.RunAll(context.Background()) }()
+
+	<-started
+	c.SetDependencies([]config.DependencyConfig{fast})
+
+	select {
+	case results := <-done:
+		if result, ok := results["slow"]; ok {
+			t.Errorf("removed dependency has a result: %+v", result)
+		}
+		if !results["fast"].OK {
+			t.Errorf("fast = %+v, want healthy", results["fast"])
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("RunAll still waiting on the removed dependency's probe")
+	}
+}
+
+func TestProbeHTTPJSONPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		jsonPath      string
+		expectedValue string
+		wantErr       bool
+	}{
+		{"degraded", `{"status":"degraded"}`, "status", "ok", true},
+		{"ok", `{"status":"ok"}`, "status", "ok", false},
+		{"nested", `{"checks":{"db":{"status":"ok"}}}`, "$.checks.db.status", "ok", false},
+		{"missing key", `{"state":"ok"}`, "status", "ok", true},
+		{"not json", `OK`, "status", "ok", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newHTTPDependency(t, "api", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.body)
+			})
+			dep.JSONPath = tt.jsonPath
+			dep.ExpectedValue = tt.expectedValue
+
+			c := NewChecker([]config.DependencyConfig{dep}, discardLogger(), nil, 5*time.Second)
+			err := c.probeHTTP(context.Background(), dep)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("probeHTTP error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunAllReportsSidecarNotReady(t *testing.T) {
+	tests := []struct {
+		name          string
+		sidecarStatus int
+		appStatus     int
+		want          string
+	}{
+		{"sidecar not ready", http.StatusServiceUnavailable, http.StatusOK, ProbeStatusSidecarNotReady},
+		{"app unhealthy", http.StatusOK, http.StatusInternalServerError, ProbeStatusUnhealthy},
+		{"both ready", http.StatusOK, http.StatusOK, ProbeStatusHealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sidecar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.sidecarStatus)
+			}))
+			defer sidecar.Close()
+
+			dep := newHTTPDependency(t, "api", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.appStatus)
+			})
+			dep.SidecarReadyURL = sidecar.URL + "/ready"
+
+			c := NewChecker([]config.DependencyConfig{dep}, discardLogger(), nil, 5*time.Second)
+			if got := c.RunAll(context.Background())["api"].Status; got != tt.want {
+				t.Errorf("status = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPProbeProducesClientSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracerProvider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	api := newHTTPDependency(t, "api", func(w http.ResponseWriter, r *http.Request) {})
+	cache := newHTTPDependency(t, "cache", func(w http.ResponseWriter, r *http.Request) {})
+	c := NewChecker([]config.DependencyConfig{api, cache}, discardLogger(), nil, 5*time.Second)
+	c.SetTracer(tracerProvider.Tracer("test"))
+
+	ctx, cycle := tracerProvider.Tracer("test").Start(context.Background(), "monitor_cycle")
+	c.RunAll(ctx)
+	cycle.End()
+
+	urls := make(map[string]bool)
+	for _, span := range recorder.Ended() {
+		if span.SpanKind() != trace.SpanKindClient {
+			continue
+		}
+		if span.SpanContext().TraceID() != cycle.SpanContext().TraceID() {
+			t.Errorf("client span %q is not in the monitoring cycle's trace", span.Name())
+		}
+		for _, attr := range span.Attributes() {
+			if attr.Key == "url.full" || attr.Key == "http.url" {
+				urls[attr.Value.AsString()] = true
+			}
+		}
+	}
+	for _, dep := range []config.DependencyConfig{api, cache} {
+		if !urls[dep.URL] {
+			t.Errorf("no HTTP client span for %s, got %v", dep.URL, urls)
+		}
+	}
+}
+
+func TestHTTPProbeEnforcesMinTLSVersion(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+
+	tests := []struct {
+		name       string
+		minVersion uint16
+		wantOK     bool
+	}{
+		{"server meets minimum", tls.VersionTLS12, true},
+		{"server below minimum", tls.VersionTLS13, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep := config.DependencyConfig{Name: "api", Type: "http", URL: srv.URL}
+			tlsCfg := &tls.Config{MinVersion: tt.minVersion, RootCAs: roots}
+
+			c := NewChecker([]config.DependencyConfig{dep}, discardLogger(), tlsCfg, 5*time.Second)
+			if result := c.RunAll(context.Background())["api"]; result.OK != tt.wantOK {
+				t.Errorf("probe = %+v, want ok %v", result, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestWarmupReportsFailuresAsInitializing(t *testing.T) {
+	tests := []struct {
+		name   string
+		warmup time.Duration
+		wait   time.Duration
+		want   string
+	}{
+		{"within warmup", time.Hour, 0, ProbeStatusInitializing},
+		{"no warmup", 0, 0, ProbeStatusUnhealthy},
+		{"after warmup", 20 * time.Millisecond, 50 * time.Millisecond, ProbeStatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newHTTPDependency(t, "api", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			})
+			c := NewChecker([]config.DependencyConfig{dep}, discardLogger(), nil, 5*time.Second)
+			c.SetWarmup(tt.warmup)
+			time.Sleep(tt.wait)
+
+			result := c.RunAll(context.Background())["api"]
+			if result.OK || result.Status != tt.want {
+				t.Errorf("result = %+v, want failed with status %q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbeBreakerBacksOffFailingDependency(t *testing.T) {
+	var probes atomic.Int64
+	dep := newHTTPDependency(t, "db-proxy", func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	dep.BreakerFailures = 3
+	dep.BreakerRecovery = 100 * time.Millisecond
+
+	c := NewChecker([]config.DependencyConfig{dep}, discardLogger(), nil, 5*time.Second)
+	runCycles := func(n int) {
+		t.Helper()
+		for range n {
+			if result := c.RunAll(context.Background())["db-proxy"]; result.OK {
+				t.Fatalf("result = %+v, want failed", result)
+			}
+		}
+	}
+
+	// The breaker opens after 3 failures; later cycles skip the probe
+	runCycles(10)
+	if got := probes.Load(); got != 3 {
+		t.Errorf("probes after 10 cycles = %d, want 3", got)
+	}
+
+	// After the recovery window a single trial probe is let through, and its
+	// failure opens the breaker again
+	time.Sleep(150 * time.Millisecond)
+	runCycles(5)
+	if got := probes.Load(); got != 4 {
+		t.Errorf("probes after recovery = %d, want 4", got)
+	}
+}
+
+func TestRunAllDispatchesProbesInBatches(t *testing.T) {
+	const (
+		batchSize     = 2
+		batchInterval = 100 * time.Millisecond
+	)
+	var mu sync.Mutex
+	arrived := make(map[string]time.Time)
+	var deps []config.DependencyConfig
+	for i := range 6 {
+		name := fmt.Sprintf("dep-%d", i)
+		deps = append(deps, newHTTPDependency(t, name, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			arrived[name] = time.Now()
+			mu.Unlock()
+		}))
+	}
+
+	c := NewChecker(deps, discardLogger(), nil, 5*time.Second)
+	c.SetProbeBatching(batchSize, batchInterval)
+	start := time.Now()
+	c.RunAll(context.Background())
+
+	for i, dep := range deps {
+		// Batch n starts n intervals in; allow for the probe's own latency
+		earliest := time.Duration(i/batchSize) * batchInterval
+		latest := earliest + batchInterval/2
+		if offset := arrived[dep.Name].Sub(start); offset < earliest || offset > latest {
+			t.Errorf("%s probed at %v, want between %v and %v", dep.Name, offset, earliest, latest)
+		}
+	}
+}
+
+func TestProbeLatencyCarriesTraceExemplar(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	metrics, reader, err := telemetry.NewTestMetrics()
+	if err != nil {
+		t.Fatalf("NewTestMetrics: %v", err)
+	}
+
+	dep := newHTTPDependency(t, "api", func(w http.ResponseWriter, r *http.Request) {})
+	c := NewChecker([]config.DependencyConfig{dep}, discardLogger(), nil, 5*time.Second)
+	c.SetTracer(tracerProvider.Tracer("test"))
+	c.SetMetrics(metrics)
+	c.RunAll(context.Background())
+
+	var probeTraceID trace.TraceID
+	for _, span := range recorder.Ended() {
+		if span.Name() == "health.probe" {
+			probeTraceID = span.SpanContext().TraceID()
+		}
+	}
+	if !probeTraceID.IsValid() {
+		t.Fatal("no health.probe span recorded")
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "raymond.dependency.probe_duration_seconds" {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				for _, ex := range point.Exemplars {
+					if trace.TraceID(ex.TraceID) == probeTraceID {
+						return
+					}
+				}
+				t.Fatalf("probe latency exemplars = %+v, want one with trace ID %s", point.Exemplars, probeTraceID)
+			}
+		}
+	}
+	t.Fatal("no probe latency recorded")
+}
+
+func TestWaitForDependenciesFailOnCriticalTimeout(t *testing.T) {
+	up := newHTTPDependency(t, "cache", func(w http.ResponseWriter, r *http.Request) {})
+	down := func(name string, critical bool) config.DependencyConfig {
+		dep := newHTTPDependency(t, name, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		dep.Critical = critical
+		return dep
+	}
+
+	tests := []struct {
+		name        string
+		deps        []config.DependencyConfig
+		failOnCrit  bool
+		wantFailing string
+	}{
+		{"critical down", []config.DependencyConfig{up, down("postgres", true)}, true, "postgres"},
+		{"only non-critical down", []config.DependencyConfig{up, down("redis", false)}, true, ""},
+		{"critical down, option off", []config.DependencyConfig{up, down("postgres", true)}, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(tt.deps, discardLogger(), nil, time.Second)
+			c.SetFailOnCriticalTimeout(tt.failOnCrit)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			err := c.WaitForDependencies(ctx)
+			if tt.wantFailing == "" {
+				if err != nil {
+					t.Errorf("WaitForDependencies = %v, want nil", err)
+				}
+				return
+			}
+
+			var depErr *pkgerrors.DependencyError
+			if !errors.Is(err, pkgerrors.ErrDependencyUnhealthy) || !errors.As(err, &depErr) || depErr.Service != tt.wantFailing {
+				t.Errorf("WaitForDependencies = %v, want %v for %s", err, pkgerrors.ErrDependencyUnhealthy, tt.wantFailing)
+			}
+		})
+	}
+}
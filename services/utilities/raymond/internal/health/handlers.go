@@ -3,35 +3,206 @@ package health
 import (
 	"log/slog"
 	"net/http"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// ReadinessFunc reports whether the service is ready, with a human-readable
+// reason.
+type ReadinessFunc func() (bool, string)
+
+// ReasonsFunc lists what is currently keeping the service from being ready,
+// e.g. bootstrap phases still running.
+type ReasonsFunc func() []string
+
+// DeepHealthResponse is the body returned by the deep health endpoint.
+type DeepHealthResponse struct {
+	Status       string                 `json:"status"`
+	Mode         string                 `json:"mode"`
+	Stale        bool                   `json:"stale"`
+	Dependencies map[string]ProbeResult `json:"dependencies"`
+}
+
+// MonitoredDependenciesResponse is the body returned by the monitored
+// dependencies endpoint.
+type MonitoredDependenciesResponse struct {
+	Status       string                 `json:"status"`
+	Mode         string                 `json:"mode"`
+	CheckedAt    time.Time              `json:"checked_at"`
+	AgeSeconds   float64                `json:"age_seconds"`
+	Dependencies map[string]ProbeResult `json:"dependencies"`
+}
+
 // Handler provides HTTP handlers for health endpoints.
 type Handler struct {
-	checker *Checker
-	logger  *slog.Logger
-	ready   atomic.Bool
+	checker   *Checker
+	deep      *resultCache
+	logger    *slog.Logger
+	ready     atomic.Bool
+	maint     atomic.Bool
+	readyAt   atomic.Int64 // unix nanoseconds of the last SetReady(true)
+	delay     time.Duration
+	readiness ReadinessFunc
+	checks    []ReadinessFunc
+	blockers  []ReasonsFunc
+
+	requireCritical bool
 }
 
 // NewHandler creates a new health handler.
 func NewHandler(checker *Checker, logger *slog.Logger) *Handler {
 	return &Handler{
 		checker: checker,
+		deep:    newResultCache(checker, 0),
 		logger:  logger,
 		ready:   atomic.Bool{},
 	}
 }
 
-// SetReady marks the service as ready.
+// SetReady marks the service as ready. With a readiness delay configured,
+// Readiness keeps reporting not ready until the delay has passed.
 func (h *Handler) SetReady(ready bool) {
+	if ready && !h.ready.Load() {
+		h.readyAt.Store(time.Now().UnixNano())
+	}
 	h.ready.Store(ready)
 }
 
+// SetReadinessDelay sets how long after SetReady(true) the service is reported
+// ready, giving connection pools time to warm. It must be called before the
+// handler starts serving.
+func (h *Handler) SetReadinessDelay(d time.Duration) {
+	h.delay = d
+}
+
+// SetDeepHealthCacheTTL makes the deep health endpoint reuse probe results
+// for up to ttl. Concurrent requests share a single probe round either way;
+// a ttl of 0 starts a new round for every request that doesn't join one. It
+// must be called before the handler starts serving.
+func (h *Handler) SetDeepHealthCacheTTL(ttl time.Duration) {
+	h.deep = newResultCache(h.checker, ttl)
+}
+
+// SetReadinessFunc replaces the default bootstrap-complete readiness logic.
+// It must be called before the handler starts serving.
+func (h *Handler) SetReadinessFunc(fn ReadinessFunc) {
+	h.readiness = fn
+}
+
+// SetMaintenance turns maintenance mode on or off. While it is on the service
+// reports not ready and deep health reports "maintenance", so load balancers
+// drain it without anything actually being broken.
+func (h *Handler) SetMaintenance(on bool) {
+	h.maint.Store(on)
+}
+
+// InMaintenance reports whether maintenance mode is on.
+func (h *Handler) InMaintenance() bool {
+	return h.maint.Load()
+}
+
+// SetRequireCriticalDependencies makes readiness also require every critical
+// dependency to be healthy in the latest probe results, so traffic stops when
+// a critical backend goes away after bootstrap. It must be called before the
+// handler starts serving.
+func (h *Handler) SetRequireCriticalDependencies(require bool) {
+	h.requireCritical = require
+}
+
+// AddReadinessCheck adds a contributor that must also report ready, on top of
+// the bootstrap or injected readiness, e.g. the telemetry pipeline. It must
+// be called before the handler starts serving.
+func (h *Handler) AddReadinessCheck(fn ReadinessFunc) {
+	h.checks = append(h.checks, fn)
+}
+
+// AddBlockingReasons adds a source of detail for the not-ready response while
+// bootstrap is incomplete, such as Status.BlockingPhases. It must be called
+// before the handler starts serving.
+func (h *Handler) AddBlockingReasons(fn ReasonsFunc) {
+	h.blockers = append(h.blockers, fn)
+}
+
+// NotReadyReasons lists everything currently keeping the service from being
+// ready: maintenance mode, incomplete bootstrap with the blocking phases,
+// unhealthy critical dependencies and failing readiness checks. It is empty
+// when the service is ready.
+func (h *Handler) NotReadyReasons() []string {
+	var reasons []string
+	if h.maint.Load() {
+		reasons = append(reasons, "maintenance mode")
+	}
+	if ready, message := h.baseReadiness(); !ready {
+		reasons = append(reasons, message)
+		for _, blocker := range h.blockers {
+			reasons = append(reasons, blocker()...)
+		}
+	}
+	for _, name := range h.failingCritical() {
+		reasons = append(reasons, "critical dependency "+name+" unhealthy")
+	}
+	for _, check := range h.checks {
+		if ok, reason := check(); !ok {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
+// Readiness reports whether the service is ready and why, using the injected
+// ReadinessFunc if one is set, and then any added readiness checks.
+// Maintenance mode overrides all of them.
+func (h *Handler) Readiness() (bool, string) {
+	if h.maint.Load() {
+		return false, "maintenance mode"
+	}
+
+	ready, message := h.baseReadiness()
+	if !ready {
+		return false, message
+	}
+	if failing := h.failingCritical(); len(failing) > 0 {
+		return false, "critical dependencies unhealthy: " + strings.Join(failing, ", ")
+	}
+	for _, check := range h.checks {
+		if ok, reason := check(); !ok {
+			return false, reason
+		}
+	}
+	return true, message
+}
+
+// failingCritical returns the unhealthy critical dependencies when readiness
+// requires them, and nil otherwise.
+func (h *Handler) failingCritical() []string {
+	if !h.requireCritical {
+		return nil
+	}
+	return h.checker.FailingCritical()
+}
+
+// baseReadiness is the injected readiness, or bootstrap completion plus the
+// readiness delay.
+func (h *Handler) baseReadiness() (bool, string) {
+	if h.readiness != nil {
+		return h.readiness()
+	}
+	if !h.ready.Load() {
+		return false, "bootstrap not complete"
+	}
+	if time.Since(time.Unix(0, h.readyAt.Load())) < h.delay {
+		return false, "bootstrap complete, waiting for readiness delay"
+	}
+	return true, "service ready"
+}
+
 // IsReady returns the readiness status.
 func (h *Handler) IsReady() bool {
-	return h.ready.Load()
+	ready, _ := h.Readiness()
+	return ready
 }
 
 // HealthHandler handles shallow health checks (fast, app alive).
@@ -44,39 +215,134 @@ func (h *Handler) HealthHandler(c *gin.Context) {
 
 // DeepHealthHandler handles deep health checks (all dependencies).
 func (h *Handler) DeepHealthHandler(c *gin.Context) {
-	results := h.checker.RunAll(c.Request.Context())
+	results := h.checker.withStaleFallback(h.deep.RunAll(c.Request.Context()))
 
 	allHealthy := true
+	initializing := false
+	stale := false
 	for _, result := range results {
-		if !result.OK {
-			allHealthy = false
+		if result.Stale {
+			stale = true
+		}
+		if result.OK {
+			continue
+		}
+		if result.Status == ProbeStatusInitializing {
+			initializing = true
+			continue
 		}
+		allHealthy = false
 	}
 
+	// Failures during the startup warmup are expected and don't fail the check,
+	// nor do results served from cache because probing itself failed
+	overall := "healthy"
 	status := http.StatusOK
-	if !allHealthy {
+	switch {
+	case h.maint.Load():
+		overall = "maintenance"
 		status = http.StatusServiceUnavailable
+	case !allHealthy:
+		overall = "unhealthy"
+		status = http.StatusServiceUnavailable
+	case stale:
+		overall = "degraded"
+	case initializing:
+		overall = ProbeStatusInitializing
 	}
 
-	c.JSON(status, gin.H{
-		"status":       map[bool]string{true: "healthy", false: "unhealthy"}[allHealthy],
-		"mode":         "deep",
-		"dependencies": results,
+	c.JSON(status, DeepHealthResponse{
+		Status:       overall,
+		Mode:         "deep",
+		Stale:        stale,
+		Dependencies: results,
 	})
 }
 
+// DependenciesHandler serves the latest background monitor results without
+// probing, so it can be polled as often as needed. age_seconds tells how old
+// the results are.
+func (h *Handler) DependenciesHandler(c *gin.Context) {
+	results, at, ok := h.checker.Monitored().Load()
+	if !ok {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":  ProbeStatusInitializing,
+			"mode":    "monitored",
+			"message": "dependencies not monitored yet",
+		})
+		return
+	}
+
+	overall := "healthy"
+	status := http.StatusOK
+	for _, result := range results {
+		if !result.OK && result.Status != ProbeStatusInitializing {
+			overall = "unhealthy"
+			status = http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	c.JSON(status, MonitoredDependenciesResponse{
+		Status:       overall,
+		Mode:         "monitored",
+		CheckedAt:    at,
+		AgeSeconds:   time.Since(at).Seconds(),
+		Dependencies: results,
+	})
+}
+
+// LivezHandler reports that the process is up. It never touches
+// dependencies, so a failing backend can't get the pod restarted.
+func (h *Handler) LivezHandler(c *gin.Context) {
+	c.String(http.StatusOK, "ok")
+}
+
+// ReadyzHandler reports readiness like ReadyHandler, as a plain-text body:
+// "ok", or the reason the service is not ready.
+func (h *Handler) ReadyzHandler(c *gin.Context) {
+	ready, message := h.Readiness()
+	if !ready {
+		c.String(http.StatusServiceUnavailable, "not ready: %s", message)
+		return
+	}
+	c.String(http.StatusOK, "ok")
+}
+
 // ReadyHandler handles readiness probe (bootstrap complete).
 func (h *Handler) ReadyHandler(c *gin.Context) {
-	if !h.IsReady() {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
+	ready, message := h.Readiness()
+	if !ready {
+		body := gin.H{
 			"ready":   false,
-			"message": "bootstrap not complete",
-		})
+			"message": message,
+		}
+		if failing := h.failingCritical(); len(failing) > 0 {
+			body["failing_dependencies"] = failing
+		}
+		if reasons := h.NotReadyReasons(); len(reasons) > 0 {
+			body["reasons"] = reasons
+		}
+		c.JSON(http.StatusServiceUnavailable, body)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"ready":   true,
-		"message": "service ready",
+		"message": message,
 	})
 }
+
+// MaintenanceOnHandler enables maintenance mode.
+func (h *Handler) MaintenanceOnHandler(c *gin.Context) {
+	h.SetMaintenance(true)
+	h.logger.Warn("maintenance mode enabled", "client_ip", c.ClientIP())
+	c.JSON(http.StatusOK, gin.H{"maintenance": true})
+}
+
+// MaintenanceOffHandler disables maintenance mode.
+func (h *Handler) MaintenanceOffHandler(c *gin.Context) {
+	h.SetMaintenance(false)
+	h.logger.Info("maintenance mode disabled", "client_ip", c.ClientIP())
+	c.JSON(http.StatusOK, gin.H{"maintenance": false})
+}
@@ -0,0 +1,248 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// readyResponse is the JSON body of /ready.
+type readyResponse struct {
+	Ready   bool     `json:"ready"`
+	Message string   `json:"message"`
+	Reasons []string `json:"reasons"`
+}
+
+// getReady serves one /ready request through h and returns the status code
+// and decoded body.
+func getReady(t *testing.T, h *Handler) (int, readyResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ready", h.ReadyHandler)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	var body readyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode /ready body %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, body
+}
+
+// getDeepHealth serves one /health/deep request through h and returns the
+// status code and decoded body.
+func getDeepHealth(t *testing.T, h *Handler) (int, DeepHealthResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/health/deep", h.DeepHealthHandler)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/deep", nil))
+
+	var body DeepHealthResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode /health/deep body %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, body
+}
+
+func TestReadyHandlerUsesInjectedReadiness(t *testing.T) {
+	h := NewHandler(nil, discardLogger())
+
+	ready, message := false, "waiting for schema registry"
+	h.SetReadinessFunc(func() (bool, string) { return ready, message })
+	// Bootstrap completion alone no longer decides readiness
+	h.SetReady(true)
+
+	code, body := getReady(t, h)
+	if code != http.StatusServiceUnavailable || body.Ready || body.Message != "waiting for schema registry" {
+		t.Errorf("/ready = %d %+v, want 503 not ready with the injected message", code, body)
+	}
+
+	ready, message = true, "schema registry reachable"
+	code, body = getReady(t, h)
+	if code != http.StatusOK || !body.Ready || body.Message != "schema registry reachable" {
+		t.Errorf("/ready = %d %+v, want 200 ready with the injected message", code, body)
+	}
+
+	// Maintenance mode still overrides the injected readiness
+	h.SetMaintenance(true)
+	if code, body = getReady(t, h); code != http.StatusServiceUnavailable || body.Message != "maintenance mode" {
+		t.Errorf("/ready in maintenance = %d %+v, want 503 maintenance mode", code, body)
+	}
+}
+
+func TestDeepHealthIncludesTypeAndTarget(t *testing.T) {
+	api := newHTTPDependency(t, "api", func(w http.ResponseWriter, r *http.Request) {})
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	nats := config.DependencyConfig{Name: "nats", Type: "tcp", Address: srv.Listener.Addr().String()}
+
+	checker := NewChecker([]config.DependencyConfig{api, nats}, discardLogger(), nil, 5*time.Second)
+	_, body := getDeepHealth(t, NewHandler(checker, discardLogger()))
+	for _, dep := range []config.DependencyConfig{api, nats} {
+		want := dep.URL
+		if dep.Type == "tcp" {
+			want = dep.Address
+		}
+		result := body.Dependencies[dep.Name]
+		if result.Type != dep.Type || result.Target != want {
+			t.Errorf("%s type, target = %q, %q, want %q, %q", dep.Name, result.Type, result.Target, dep.Type, want)
+		}
+	}
+}
+
+func TestDeepHealthDuringWarmup(t *testing.T) {
+	dep := newHTTPDependency(t, "api", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	checker := NewChecker([]config.DependencyConfig{dep}, discardLogger(), nil, 5*time.Second)
+	checker.SetWarmup(time.Hour)
+
+	code, body := getDeepHealth(t, NewHandler(checker, discardLogger()))
+	if code != http.StatusOK || body.Status != ProbeStatusInitializing {
+		t.Errorf("/health/deep = %d %q, want 200 %q", code, body.Status, ProbeStatusInitializing)
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestDeepHealthServesStaleResultsWhenProbingFails(t *testing.T) {
+	dep := newHTTPDependency(t, "api", func(w http.ResponseWriter, r *http.Request) {})
+	checker := NewChecker([]config.DependencyConfig{dep}, discardLogger(), nil, 5*time.Second)
+	h := NewHandler(checker, discardLogger())
+
+	if code, body := getDeepHealth(t, h); code != http.StatusOK || body.Stale {
+		t.Fatalf("first /health/deep = %d %+v, want fresh and healthy", code, body)
+	}
+
+	// The process runs out of file descriptors, so no probe can even connect
+	checker.httpClient.Transport = roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("socket", syscall.EMFILE)}
+	})
+
+	code, body := getDeepHealth(t, h)
+	if code != http.StatusOK || body.Status != "degraded" || !body.Stale {
+		t.Errorf("/health/deep = %d status %q stale %v, want 200 degraded and stale", code, body.Status, body.Stale)
+	}
+	if result := body.Dependencies["api"]; !result.OK || !result.Stale {
+		t.Errorf("api = %+v, want the last healthy result marked stale", result)
+	}
+}
+
+func TestReadyHandlerWaitsForReadinessDelay(t *testing.T) {
+	const delay = 200 * time.Millisecond
+	h := NewHandler(nil, discardLogger())
+	h.SetReadinessDelay(delay)
+
+	readyAt := time.Now()
+	h.SetReady(true)
+	if code, body := getReady(t, h); code != http.StatusServiceUnavailable || body.Ready {
+		t.Errorf("/ready right after bootstrap = %d %+v, want 503 not ready", code, body)
+	}
+
+	deadline := readyAt.Add(5 * time.Second)
+	for {
+		code, body := getReady(t, h)
+		if code == http.StatusOK && body.Ready {
+			if waited := time.Since(readyAt); waited < delay {
+				t.Errorf("/ready flipped to 200 after %v, before the %v delay", waited, delay)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("/ready = %d %+v, still not ready well after the %v delay", code, body, delay)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReadyHandlerRequiresAddedChecks(t *testing.T) {
+	h := NewHandler(nil, discardLogger())
+	h.SetReady(true)
+
+	telemetryReady := false
+	h.AddReadinessCheck(func() (bool, string) {
+		if !telemetryReady {
+			return false, "telemetry collector otel:4317 is transient_failure"
+		}
+		return true, "telemetry pipeline connected"
+	})
+
+	code, body := getReady(t, h)
+	if code != http.StatusServiceUnavailable || body.Ready || body.Message != "telemetry collector otel:4317 is transient_failure" {
+		t.Errorf("/ready with telemetry down = %d %+v, want 503 with the telemetry reason", code, body)
+	}
+
+	telemetryReady = true
+	if code, body = getReady(t, h); code != http.StatusOK || !body.Ready {
+		t.Errorf("/ready with telemetry up = %d %+v, want 200 ready", code, body)
+	}
+
+	// Added checks don't make a service ready before bootstrap completes
+	h.SetReady(false)
+	if code, body = getReady(t, h); code != http.StatusServiceUnavailable || body.Ready {
+		t.Errorf("/ready before bootstrap = %d %+v, want 503 not ready", code, body)
+	}
+}
+
+func TestConcurrentDeepHealthSharesProbeRound(t *testing.T) {
+	for _, ttl := range []time.Duration{0, time.Minute} {
+		t.Run(fmt.Sprintf("cache_ttl=%v", ttl), func(t *testing.T) {
+			var probes atomic.Int64
+			dep := newHTTPDependency(t, "api", func(w http.ResponseWriter, r *http.Request) {
+				probes.Add(1)
+				// Hold the round open while the other requests arrive
+				time.Sleep(200 * time.Millisecond)
+			})
+			checker := NewChecker([]config.DependencyConfig{dep}, discardLogger(), nil, 5*time.Second)
+			h := NewHandler(checker, discardLogger())
+			h.SetDeepHealthCacheTTL(ttl)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/health/deep", h.DeepHealthHandler)
+
+			const requests = 20
+			codes := make(chan int, requests)
+			var wg sync.WaitGroup
+			for range requests {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					rec := httptest.NewRecorder()
+					router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/deep", nil))
+					codes <- rec.Code
+				}()
+			}
+			wg.Wait()
+			close(codes)
+
+			for code := range codes {
+				if code != http.StatusOK {
+					t.Errorf("/health/deep = %d, want %d", code, http.StatusOK)
+				}
+			}
+			if got := probes.Load(); got != 1 {
+				t.Errorf("%d concurrent requests ran %d probe rounds, want 1", requests, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package health
+
+// historySize is the number of recent probe outcomes kept per dependency.
+const historySize = 10
+
+// resultHistory is a fixed-size ring buffer of recent probe outcomes.
+type resultHistory struct {
+	results [historySize]bool
+	next    int
+	count   int
+}
+
+// add records a probe outcome, overwriting the oldest once the buffer is full.
+func (h *resultHistory) add(ok bool) {
+	h.results[h.next] = ok
+	h.next = (h.next + 1) % historySize
+	if h.count < historySize {
+		h.count++
+	}
+}
+
+// flaps returns the number of healthy/unhealthy transitions in the buffer.
+func (h *resultHistory) flaps() int {
+	if h.count < 2 {
+		return 0
+	}
+
+	oldest := (h.next - h.count + historySize) % historySize
+	flaps := 0
+	prev := h.results[oldest]
+	for i := 1; i < h.count; i++ {
+		cur := h.results[(oldest+i)%historySize]
+		if cur != prev {
+			flaps++
+		}
+		prev = cur
+	}
+	return flaps
+}
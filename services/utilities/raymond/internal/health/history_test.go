@@ -0,0 +1,34 @@
+package health
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlapCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []bool
+		want    int
+	}{
+		{"no history", nil, 0},
+		{"single result", []bool{false}, 0},
+		{"steady", []bool{true, true, true, true}, 0},
+		{"one outage", []bool{true, false, false, true}, 2},
+		{"alternating", []bool{true, false, true, false, true, false}, 5},
+		// Only the last historySize results count; the leading flaps roll off
+		{"wraps", []bool{true, false, true, false, true, true, true, true, true, true, true, true, false, false}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(nil, discardLogger(), nil, time.Second)
+			for _, ok := range tt.results {
+				c.recordHistory(ProbeResult{Name: "nats", OK: ok})
+			}
+			if got := c.FlapCount("nats"); got != tt.want {
+				t.Errorf("FlapCount = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
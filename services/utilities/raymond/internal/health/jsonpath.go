@@ -0,0 +1,59 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxProbeBodyBytes caps how much of an HTTP probe response is read when
+// matching a JSON path.
+const maxProbeBodyBytes = 1 << 20
+
+// maxBodyMatchBytes caps how much of an HTTP probe response is searched for
+// expect_body_contains when no JSON path needs the larger limit.
+const maxBodyMatchBytes = 64 << 10
+
+// lookupJSONPath returns the value at a dot-separated path (e.g. "status" or
+// "checks.db.status", optionally prefixed with "$.") in a JSON document,
+// formatted as a string. Array elements are addressed by index.
+func lookupJSONPath(body []byte, path string) (string, error) {
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return "", fmt.Errorf("parse response body: %w", err)
+	}
+
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	current := doc
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[key]
+			if !ok {
+				return "", fmt.Errorf("json path %q: key %q not found", path, key)
+			}
+			current = value
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return "", fmt.Errorf("json path %q: invalid index %q", path, key)
+			}
+			current = node[i]
+		default:
+			return "", fmt.Errorf("json path %q: cannot descend into %q", path, key)
+		}
+	}
+
+	switch value := current.(type) {
+	case string:
+		return value, nil
+	case nil:
+		return "null", nil
+	case map[string]any, []any:
+		encoded, _ := json.Marshal(value)
+		return string(encoded), nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
@@ -0,0 +1,36 @@
+package health
+
+import "testing"
+
+func TestLookupJSONPath(t *testing.T) {
+	body := []byte(`{"status":"ok","checks":{"db":{"up":true,"latency":12}},"nodes":[{"name":"a"},{"name":"b"}],"leader":null}`)
+
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"status", "ok", false},
+		{"$.status", "ok", false},
+		{"checks.db.up", "true", false},
+		{"checks.db.latency", "12", false},
+		{"nodes.1.name", "b", false},
+		{"leader", "null", false},
+		{"checks.db", `{"latency":12,"up":true}`, false},
+		{"checks.cache", "", true},
+		{"nodes.2.name", "", true},
+		{"status.code", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := lookupJSONPath(body, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("lookupJSONPath error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("lookupJSONPath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+// Snapshot holds the latest results of the background dependency monitor,
+// so they can be served without probing again.
+type Snapshot struct {
+	mu      sync.RWMutex
+	results map[string]ProbeResult
+	at      time.Time
+}
+
+// Store replaces the snapshot with results checked now.
+func (s *Snapshot) Store(results map[string]ProbeResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = results
+	s.at = time.Now()
+}
+
+// Load returns the stored results and when they were checked. ok is false
+// until the first Store.
+func (s *Snapshot) Load() (results map[string]ProbeResult, at time.Time, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.results, s.at, s.results != nil
+}
@@ -0,0 +1,149 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+// consulCriticalTag marks a discovered Consul service as a critical dependency.
+const consulCriticalTag = "critical"
+
+// DependencySource supplies the set of dependencies to probe.
+type DependencySource interface {
+	Dependencies(ctx context.Context) ([]config.DependencyConfig, error)
+}
+
+// StaticSource is a fixed list of dependencies, normally from config.
+type StaticSource []config.DependencyConfig
+
+// Dependencies returns the static list.
+func (s StaticSource) Dependencies(context.Context) ([]config.DependencyConfig, error) {
+	return s, nil
+}
+
+// ConsulSource discovers dependencies from the Consul catalog. Every service
+// carrying the configured tag becomes a TCP dependency on its first
+// registered instance; services also tagged "critical" are marked critical.
+type ConsulSource struct {
+	address string
+	tag     string
+	client  *http.Client
+}
+
+// NewConsulSource creates a source reading the catalog of the Consul agent at
+// address (e.g. http://consul:8500). Discovered dependencies use the
+// checker's default probe timeout.
+func NewConsulSource(address, tag string) *ConsulSource {
+	return &ConsulSource{
+		address: address,
+		tag:     tag,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// consulCatalogService is the subset of a /v1/catalog/service entry used here.
+type consulCatalogService struct {
+	Address        string
+	ServiceAddress string
+	ServicePort    int
+	ServiceTags    []string
+}
+
+// Dependencies lists the tagged services and resolves each to an address.
+func (s *ConsulSource) Dependencies(ctx context.Context) ([]config.DependencyConfig, error) {
+	var services map[string][]string
+	if err := s.get(ctx, "/v1/catalog/services", &services); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(services))
+	for name, tags := range services {
+		if slices.Contains(tags, s.tag) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	deps := make([]config.DependencyConfig, 0, len(names))
+	for _, name := range names {
+		var instances []consulCatalogService
+		path := "/v1/catalog/service/" + url.PathEscape(name) + "?tag=" + url.QueryEscape(s.tag)
+		if err := s.get(ctx, path, &instances); err != nil {
+			return nil, err
+		}
+		if len(instances) == 0 {
+			continue
+		}
+
+		inst := instances[0]
+		host := inst.ServiceAddress
+		if host == "" {
+			host = inst.Address
+		}
+		deps = append(deps, config.DependencyConfig{
+			Name:     name,
+			Type:     "tcp",
+			Address:  net.JoinHostPort(host, strconv.Itoa(inst.ServicePort)),
+			Critical: slices.Contains(inst.ServiceTags, consulCriticalTag),
+		})
+	}
+	return deps, nil
+}
+
+// get decodes the JSON response of a Consul HTTP API request into out.
+func (s *ConsulSource) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.address+path, nil)
+	if err != nil {
+		return fmt.Errorf("create consul request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("consul request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("consul %s: unexpected status code: %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode consul response: %w", err)
+	}
+	return nil
+}
+
+// WatchSource refreshes the checker's dependencies from src every interval
+// until ctx is canceled. A failed refresh keeps the current list.
+func (c *Checker) WatchSource(ctx context.Context, src DependencySource, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	refresh := func() {
+		deps, err := src.Dependencies(ctx)
+		if err != nil {
+			c.logger.Warn("failed to refresh dependencies, keeping current list", "error", err)
+			return
+		}
+		c.SetDependencies(deps)
+	}
+
+	refresh()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
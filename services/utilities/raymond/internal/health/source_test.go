@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+// fakeSource is a DependencySource whose list can change between refreshes.
+type fakeSource struct {
+	mu   sync.Mutex
+	deps []config.DependencyConfig
+	err  error
+}
+
+func (s *fakeSource) Dependencies(context.Context) ([]config.DependencyConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deps, s.err
+}
+
+func (s *fakeSource) set(deps []config.DependencyConfig, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deps, s.err = deps, err
+}
+
+// waitForDependencies polls c until its dependency names equal want.
+func waitForDependencies(t *testing.T, c *Checker, want ...string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var names []string
+		for _, dep := range c.Dependencies() {
+			names = append(names, dep.Name)
+		}
+		if reflect.DeepEqual(names, want) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("dependencies = %v, want %v", names, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatchSourcePicksUpNewDependency(t *testing.T) {
+	api := newHTTPDependency(t, "api", func(w http.ResponseWriter, r *http.Request) {})
+	cache := newHTTPDependency(t, "cache", func(w http.ResponseWriter, r *http.Request) {})
+	src := &fakeSource{deps: []config.DependencyConfig{api}}
+
+	c := NewChecker(nil, discardLogger(), nil, 5*time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.WatchSource(ctx, src, 20*time.Millisecond)
+
+	waitForDependencies(t, c, "api")
+
+	src.set([]config.DependencyConfig{api, cache}, nil)
+	waitForDependencies(t, c, "api", "cache")
+	if result, ok := c.RunAll(context.Background())["cache"]; !ok || !result.OK {
+		t.Errorf("cache = %+v, want probed and healthy", result)
+	}
+
+	// A failed refresh keeps the last known list
+	src.set(nil, errors.New("registry unavailable"))
+	time.Sleep(60 * time.Millisecond)
+	waitForDependencies(t, c, "api", "cache")
+}
+
+func TestConsulSourceDependencies(t *testing.T) {
+	consul := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/catalog/services":
+			w.Write([]byte(`{"nats":["raymond","critical"],"redis":["raymond"],"billing":["web"]}`))
+		case "/v1/catalog/service/nats":
+			w.Write([]byte(`[{"Address":"10.0.0.5","ServiceAddress":"","ServicePort":4222,"ServiceTags":["raymond","critical"]}]`))
+		case "/v1/catalog/service/redis":
+			w.Write([]byte(`[{"Address":"10.0.0.6","ServiceAddress":"10.0.1.6","ServicePort":6379,"ServiceTags":["raymond"]}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer consul.Close()
+
+	deps, err := NewConsulSource(consul.URL, "raymond").Dependencies(context.Background())
+	if err != nil {
+		t.Fatalf("Dependencies: %v", err)
+	}
+	want := []config.DependencyConfig{
+		{Name: "nats", Type: "tcp", Address: "10.0.0.5:4222", Critical: true},
+		{Name: "redis", Type: "tcp", Address: "10.0.1.6:6379"},
+	}
+	if !reflect.DeepEqual(deps, want) {
+		t.Errorf("dependencies = %+v, want %+v", deps, want)
+	}
+}
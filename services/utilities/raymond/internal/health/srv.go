@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+// srvResolver looks up DNS SRV records. *net.Resolver implements it.
+type srvResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// SetResolver replaces the resolver used for srv probes. It must be called
+// before probing starts.
+func (c *Checker) SetResolver(r srvResolver) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resolver = r
+}
+
+// probeSRV resolves the dependency's SRV record and TCP-probes the targets in
+// priority order, succeeding as soon as one is reachable.
+func (c *Checker) probeSRV(ctx context.Context, dep config.DependencyConfig) error {
+	c.mu.RLock()
+	resolver := c.resolver
+	c.mu.RUnlock()
+
+	_, records, err := resolver.LookupSRV(ctx, dep.SRVService, srvProto(dep), dep.Address)
+	if err != nil {
+		return fmt.Errorf("srv lookup failed: %w", err)
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("srv lookup returned no targets for %s", srvName(dep))
+	}
+
+	var errs []error
+	for _, srv := range records {
+		address := net.JoinHostPort(srv.Target, strconv.Itoa(int(srv.Port)))
+		err := c.probeTCP(ctx, address)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", address, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return fmt.Errorf("no srv target reachable: %w", errors.Join(errs...))
+}
+
+// srvProto returns the SRV protocol label for dep, defaulting to tcp.
+func srvProto(dep config.DependencyConfig) string {
+	if dep.SRVProto == "" {
+		return "tcp"
+	}
+	return dep.SRVProto
+}
+
+// srvName returns the full SRV record name queried for dep, for reporting.
+func srvName(dep config.DependencyConfig) string {
+	return fmt.Sprintf("_%s._%s.%s", dep.SRVService, srvProto(dep), dep.Address)
+}
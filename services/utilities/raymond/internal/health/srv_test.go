@@ -0,0 +1,112 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+// fakeResolver answers SRV lookups with fixed records, keeping the last query.
+type fakeResolver struct {
+	records []*net.SRV
+	err     error
+	query   string
+}
+
+func (r *fakeResolver) LookupSRV(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	r.query = "_" + service + "._" + proto + "." + name
+	return r.query, r.records, r.err
+}
+
+// srvTarget returns an SRV record pointing at addr.
+func srvTarget(t *testing.T, addr string) *net.SRV {
+	t.Helper()
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("split %s: %v", addr, err)
+	}
+	p, _ := strconv.Atoi(port)
+	return &net.SRV{Target: host, Port: uint16(p)}
+}
+
+// listening returns the address of a listener that accepts connections.
+func listening(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// closed returns a local address nothing listens on.
+func closed(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+	return addr
+}
+
+func TestProbeSRV(t *testing.T) {
+	up, down := listening(t), closed(t)
+
+	tests := []struct {
+		name    string
+		targets []string
+		err     error
+		wantErr string
+	}{
+		{"first target reachable", []string{up, down}, nil, ""},
+		{"later target reachable", []string{down, up}, nil, ""},
+		{"no target reachable", []string{down}, nil, "no srv target reachable"},
+		{"no targets", nil, nil, "returned no targets"},
+		{"lookup fails", nil, errors.New("no such host"), "srv lookup failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &fakeResolver{err: tt.err}
+			for _, addr := range tt.targets {
+				resolver.records = append(resolver.records, srvTarget(t, addr))
+			}
+			dep := config.DependencyConfig{Name: "pulsar", Type: "srv", Address: "service.consul", SRVService: "pulsar"}
+
+			c := NewChecker([]config.DependencyConfig{dep}, discardLogger(), nil, 5*time.Second)
+			c.SetResolver(resolver)
+			result := c.RunAll(context.Background())["pulsar"]
+
+			if resolver.query != "_pulsar._tcp.service.consul" {
+				t.Errorf("SRV query = %q, want %q", resolver.query, "_pulsar._tcp.service.consul")
+			}
+			if tt.wantErr == "" {
+				if !result.OK {
+					t.Errorf("result = %+v, want healthy", result)
+				}
+				return
+			}
+			if result.OK || !strings.Contains(result.Error, tt.wantErr) {
+				t.Errorf("result = %+v, want failed with %q", result, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package health
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+// probeTarget returns the address or URL a dependency's probe contacts, for
+// reporting.
+func probeTarget(dep config.DependencyConfig) string {
+	switch dep.Type {
+	case "srv":
+		return srvName(dep)
+	case "http":
+		if target, err := httpTarget(dep); err == nil {
+			return target
+		}
+		return dep.URL
+	default:
+		return dialTarget(dep)
+	}
+}
+
+// dialTarget returns the host:port to dial for a tcp or grpc dependency. When
+// the dependency sets a port, it replaces any port in the address, so the
+// address can hold just a host filled in by service discovery.
+func dialTarget(dep config.DependencyConfig) string {
+	if dep.Port == 0 {
+		return dep.Address
+	}
+
+	host := dep.Address
+	if h, _, err := net.SplitHostPort(dep.Address); err == nil {
+		host = h
+	}
+	return net.JoinHostPort(host, strconv.Itoa(dep.Port))
+}
+
+// httpTarget returns the URL to request for an http dependency, with the port
+// replaced by the dependency's port when one is set.
+func httpTarget(dep config.DependencyConfig) (string, error) {
+	if dep.Port == 0 {
+		return dep.URL, nil
+	}
+
+	u, err := url.Parse(dep.URL)
+	if err != nil {
+		return "", fmt.Errorf("parse url: %w", err)
+	}
+	u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(dep.Port))
+	return u.String(), nil
+}
@@ -0,0 +1,31 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+func TestProbeTarget(t *testing.T) {
+	tests := []struct {
+		name string
+		dep  config.DependencyConfig
+		want string
+	}{
+		{"tcp address", config.DependencyConfig{Type: "tcp", Address: "arc-flash:4222"}, "arc-flash:4222"},
+		{"tcp host and port", config.DependencyConfig{Type: "tcp", Address: "arc-flash", Port: 4222}, "arc-flash:4222"},
+		{"tcp port overrides address port", config.DependencyConfig{Type: "tcp", Address: "arc-flash:1234", Port: 4222}, "arc-flash:4222"},
+		{"grpc ipv6 host", config.DependencyConfig{Type: "grpc", Address: "::1", Port: 4317}, "[::1]:4317"},
+		{"http url", config.DependencyConfig{Type: "http", URL: "http://arc-strange:8080/admin/v2/brokers/health"}, "http://arc-strange:8080/admin/v2/brokers/health"},
+		{"http port added", config.DependencyConfig{Type: "http", URL: "http://arc-strange/admin/v2/brokers/health", Port: 8080}, "http://arc-strange:8080/admin/v2/brokers/health"},
+		{"http port replaced", config.DependencyConfig{Type: "http", URL: "https://arc-strange:443/ready?full=1", Port: 8443}, "https://arc-strange:8443/ready?full=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := probeTarget(tt.dep); got != tt.want {
+				t.Errorf("probeTarget = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
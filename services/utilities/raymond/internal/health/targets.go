@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+// TargetResult is the outcome of probing one of a dependency's targets.
+type TargetResult struct {
+	Target    string
+	OK        bool
+	LatencyMS int64
+	Error     string `json:",omitempty"`
+}
+
+// withTarget returns dep pointed at a single one of its targets.
+func withTarget(dep config.DependencyConfig, target config.ProbeTargetConfig) config.DependencyConfig {
+	dep.Targets = nil
+	if dep.Type == "http" {
+		dep.URL = target.URL
+	} else {
+		dep.Address = target.Address
+	}
+	return dep
+}
+
+// pickTargets chooses up to n of targets at random, favoring higher weights,
+// so that over successive cycles every target gets probed. n of 0 or more
+// than the number of targets selects all of them.
+func pickTargets(targets []config.ProbeTargetConfig, n int) []config.ProbeTargetConfig {
+	if n <= 0 || n >= len(targets) {
+		return targets
+	}
+
+	// Weighted sampling without replacement: each target gets the key
+	// u^(1/weight) and the n largest keys win
+	type keyed struct {
+		target config.ProbeTargetConfig
+		key    float64
+	}
+	keys := make([]keyed, len(targets))
+	for i, t := range targets {
+		weight := float64(t.Weight)
+		if weight <= 0 {
+			weight = 1
+		}
+		keys[i] = keyed{target: t, key: math.Pow(rand.Float64(), 1/weight)}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].key > keys[j].key })
+
+	picked := make([]config.ProbeTargetConfig, n)
+	for i := range picked {
+		picked[i] = keys[i].target
+	}
+	return picked
+}
+
+// probeTargets probes a subset of dep's targets concurrently and returns the
+// per-target results. The error is non-nil when any probed target failed.
+func (c *Checker) probeTargets(ctx context.Context, dep config.DependencyConfig) ([]TargetResult, error) {
+	picked := pickTargets(dep.Targets, dep.TargetsPerProbe)
+	results := make([]TargetResult, len(picked))
+	errs := make([]error, len(picked))
+
+	var wg sync.WaitGroup
+	for i, target := range picked {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			single := withTarget(dep, target)
+			start := time.Now()
+			err := c.probe(ctx, single)
+			results[i] = TargetResult{
+				Target:    probeTarget(single),
+				OK:        err == nil,
+				LatencyMS: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				results[i].Error = err.Error()
+				errs[i] = fmt.Errorf("%s: %w", results[i].Target, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d targets unhealthy: %w", failed, len(results), errors.Join(errs...))
+	}
+	return results, nil
+}
+
+// targetsLabel lists the probed targets for ProbeResult.Target.
+func targetsLabel(results []TargetResult) string {
+	targets := make([]string, len(results))
+	for i, r := range results {
+		targets[i] = r.Target
+	}
+	return strings.Join(targets, ",")
+}
+
+// anyTargetOK reports whether at least one probed target was healthy.
+func anyTargetOK(results []TargetResult) bool {
+	for _, r := range results {
+		if r.OK {
+			return true
+		}
+	}
+	return false
+}
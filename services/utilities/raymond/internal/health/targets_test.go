@@ -0,0 +1,76 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+func TestProbeMultipleTargets(t *testing.T) {
+	up := newHTTPDependency(t, "up", func(w http.ResponseWriter, r *http.Request) {})
+	down := newHTTPDependency(t, "down", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	dep := config.DependencyConfig{
+		Name: "api",
+		Type: "http",
+		Targets: []config.ProbeTargetConfig{
+			{URL: up.URL},
+			{URL: down.URL},
+		},
+	}
+
+	c := NewChecker([]config.DependencyConfig{dep}, discardLogger(), nil, 5*time.Second)
+	result := c.RunAll(context.Background())["api"]
+	if result.OK || result.Status != ProbeStatusDegraded {
+		t.Errorf("result = %+v, want failed with status %q", result, ProbeStatusDegraded)
+	}
+	if !strings.Contains(result.Error, "1 of 2 targets unhealthy") {
+		t.Errorf("error = %q, want it to count the failed target", result.Error)
+	}
+
+	if len(result.Targets) != 2 {
+		t.Fatalf("targets = %+v, want one result per target", result.Targets)
+	}
+	byTarget := make(map[string]TargetResult)
+	for _, r := range result.Targets {
+		byTarget[r.Target] = r
+	}
+	if r := byTarget[up.URL]; !r.OK || r.Error != "" {
+		t.Errorf("%s = %+v, want healthy", up.URL, r)
+	}
+	if r := byTarget[down.URL]; r.OK || r.Error == "" {
+		t.Errorf("%s = %+v, want failed with an error", down.URL, r)
+	}
+}
+
+func TestPickTargets(t *testing.T) {
+	targets := []config.ProbeTargetConfig{
+		{Address: "a:1", Weight: 1},
+		{Address: "b:1", Weight: 5},
+		{Address: "c:1"},
+	}
+
+	tests := []struct {
+		n    int
+		want int
+	}{
+		{0, 3},
+		{2, 2},
+		{5, 3},
+	}
+	for _, tt := range tests {
+		picked := pickTargets(targets, tt.n)
+		seen := make(map[string]bool)
+		for _, target := range picked {
+			seen[target.Address] = true
+		}
+		if len(picked) != tt.want || len(seen) != tt.want {
+			t.Errorf("pickTargets(n=%d) = %+v, want %d distinct targets", tt.n, picked, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// HTTPTiming breaks an HTTP probe's latency down by phase. Phases that didn't
+// happen, such as DNS and connect on a reused connection, are 0.
+type HTTPTiming struct {
+	DNSMS          int64
+	ConnectMS      int64
+	TLSHandshakeMS int64
+	FirstByteMS    int64 // from sending the request to the first response byte
+}
+
+// timingRecorder collects httptrace callbacks for one probe. Callbacks can
+// come from the transport's dialing goroutines, hence the mutex.
+type timingRecorder struct {
+	mu                               sync.Mutex
+	dnsStart, connectStart, tlsStart time.Time
+	wroteRequest                     time.Time
+	timing                           HTTPTiming
+}
+
+type timingRecorderKey struct{}
+
+// withTimingRecorder makes HTTP probes run with ctx record their phase timings
+// into r.
+func withTimingRecorder(ctx context.Context, r *timingRecorder) context.Context {
+	return context.WithValue(ctx, timingRecorderKey{}, r)
+}
+
+// traceTiming attaches an httptrace.ClientTrace to ctx when it carries a
+// timing recorder.
+func traceTiming(ctx context.Context) context.Context {
+	r, ok := ctx.Value(timingRecorderKey{}).(*timingRecorder)
+	if !ok {
+		return ctx
+	}
+
+	since := func(start time.Time) int64 {
+		return time.Since(start).Milliseconds()
+	}
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			r.mu.Lock()
+			r.dnsStart = time.Now()
+			r.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			r.mu.Lock()
+			r.timing.DNSMS = since(r.dnsStart)
+			r.mu.Unlock()
+		},
+		ConnectStart: func(string, string) {
+			r.mu.Lock()
+			r.connectStart = time.Now()
+			r.mu.Unlock()
+		},
+		ConnectDone: func(string, string, error) {
+			r.mu.Lock()
+			r.timing.ConnectMS = since(r.connectStart)
+			r.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			r.mu.Lock()
+			r.tlsStart = time.Now()
+			r.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			r.mu.Lock()
+			r.timing.TLSHandshakeMS = since(r.tlsStart)
+			r.mu.Unlock()
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			r.mu.Lock()
+			r.wroteRequest = time.Now()
+			r.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			r.mu.Lock()
+			r.timing.FirstByteMS = since(r.wroteRequest)
+			r.mu.Unlock()
+		},
+	})
+}
+
+// result returns the recorded timings.
+func (r *timingRecorder) result() *HTTPTiming {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	timing := r.timing
+	return &timing
+}
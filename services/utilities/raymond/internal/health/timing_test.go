@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+func TestHTTPProbeDetailedTiming(t *testing.T) {
+	const (
+		handshakeDelay = 30 * time.Millisecond
+		serverDelay    = 50 * time.Millisecond
+	)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(serverDelay)
+	}))
+	// Slow the handshake down so its phase is measurable
+	srv.TLS = &tls.Config{GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		time.Sleep(handshakeDelay)
+		return nil, nil
+	}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	tlsCfg := &tls.Config{RootCAs: roots}
+
+	tests := []struct {
+		name           string
+		detailedTiming bool
+	}{
+		{"detailed timing", true},
+		{"no detailed timing", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep := config.DependencyConfig{Name: "api", Type: "http", URL: srv.URL, DetailedTiming: tt.detailedTiming}
+			c := NewChecker([]config.DependencyConfig{dep}, discardLogger(), tlsCfg, 5*time.Second)
+
+			result := c.RunAll(context.Background())["api"]
+			if !result.OK {
+				t.Fatalf("result = %+v, want healthy", result)
+			}
+			if !tt.detailedTiming {
+				if result.Timing != nil {
+					t.Errorf("timing = %+v, want none without detailed_timing", result.Timing)
+				}
+				return
+			}
+
+			timing := result.Timing
+			if timing == nil {
+				t.Fatal("timing = nil, want phase timings")
+			}
+			if timing.TLSHandshakeMS < handshakeDelay.Milliseconds() {
+				t.Errorf("TLS handshake = %dms, want at least %v", timing.TLSHandshakeMS, handshakeDelay)
+			}
+			if timing.FirstByteMS < serverDelay.Milliseconds() {
+				t.Errorf("first byte = %dms, want at least %v", timing.FirstByteMS, serverDelay)
+			}
+			if timing.FirstByteMS > result.LatencyMS || timing.TLSHandshakeMS > result.LatencyMS {
+				t.Errorf("timing = %+v exceeds total latency %dms", timing, result.LatencyMS)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Gzip compresses responses of at least minSize bytes for clients that send
+// Accept-Encoding: gzip. The response is buffered until the handler returns
+// so the size is known; the status and headers are written only then, which
+// keeps Content-Length consistent and lets outer middleware such as otelgin
+// and RequestLogger see the final status.
+func Gzip(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := &gzipWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = w
+		// Restored even on panic so Recovery writes its 500 uncompressed
+		defer func() { c.Writer = original }()
+
+		c.Next()
+		w.finish(minSize)
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		// An explicit q=0 means "not acceptable"
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			weight, err := strconv.ParseFloat(q, 64)
+			return err == nil && weight > 0
+		}
+		return true
+	}
+	return false
+}
+
+// gzipWriter buffers the response body and status until finish.
+type gzipWriter struct {
+	gin.ResponseWriter
+	buf     bytes.Buffer
+	status  int
+	written bool
+}
+
+func (w *gzipWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *gzipWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.buf.Write(data)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.buf.WriteString(s)
+}
+
+func (w *gzipWriter) Status() int {
+	return w.status
+}
+
+func (w *gzipWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.buf.Len()
+}
+
+func (w *gzipWriter) Written() bool {
+	return w.written
+}
+
+// Flush is a no-op: the body is sent in one piece by finish.
+func (w *gzipWriter) Flush() {}
+
+// finish writes the buffered response to the underlying writer, compressed
+// when it is large enough and not already encoded.
+func (w *gzipWriter) finish(minSize int) {
+	header := w.ResponseWriter.Header()
+	header.Add("Vary", "Accept-Encoding")
+
+	if w.buf.Len() < minSize || header.Get("Content-Encoding") != "" {
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.Write(w.buf.Bytes())
+		return
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+
+	gz := gzip.NewWriter(w.ResponseWriter)
+	gz.Write(w.buf.Bytes())
+	gz.Close()
+}
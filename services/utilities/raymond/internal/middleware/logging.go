@@ -26,6 +26,7 @@ func RequestLogger(logger *slog.Logger, metrics *telemetry.Metrics) gin.HandlerF
 			"status", status,
 			"duration_ms", duration.Milliseconds(),
 			"client_ip", c.ClientIP(),
+			"request_id", GetRequestID(c),
 		)
 
 		if metrics != nil {
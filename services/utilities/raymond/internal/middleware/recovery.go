@@ -1,28 +1,73 @@
 package middleware
 
 import (
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
+	"runtime/debug"
+	"strings"
+	"syscall"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Recovery handles panics and returns a 500 error.
+// Panics caused by the client going away (broken pipe, reset connection) are
+// logged at debug without a stack trace since there is nothing to fix; all
+// other panics are logged at error with the full stack.
 func Recovery(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
+				if isClientDisconnect(err) {
+					logger.Debug("client disconnected during response",
+						"error", err,
+						"path", c.Request.URL.Path,
+						"method", c.Request.Method,
+					)
+					// The connection is gone, so no response can be written
+					c.Abort()
+					return
+				}
+
 				logger.Error("panic recovered",
 					"error", err,
 					"path", c.Request.URL.Path,
 					"method", c.Request.Method,
+					"stack", string(debug.Stack()),
 				)
 
 				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-					"error": "internal server error",
+					"error":      "internal server error",
+					"request_id": GetRequestID(c),
 				})
 			}
 		}()
 		c.Next()
 	}
 }
+
+// isClientDisconnect reports whether a recovered panic value was caused by the
+// client closing the connection rather than a bug in the handler.
+func isClientDisconnect(recovered any) bool {
+	err, ok := recovered.(error)
+	if !ok {
+		return false
+	}
+
+	if errors.Is(err, http.ErrAbortHandler) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Err != nil {
+		msg := strings.ToLower(opErr.Err.Error())
+		return strings.Contains(msg, "broken pipe") || strings.Contains(msg, "connection reset by peer")
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRecoveryLogLevel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		handler    gin.HandlerFunc
+		wantLevel  string
+		wantStack  bool
+		wantStatus int
+	}{
+		{
+			name: "broken pipe",
+			handler: func(c *gin.Context) {
+				panic(&net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)})
+			},
+			wantLevel:  "DEBUG",
+			wantStack:  false,
+			wantStatus: http.StatusOK, // nothing is written to a closed connection
+		},
+		{
+			name: "nil dereference",
+			handler: func(c *gin.Context) {
+				var header *http.Header
+				c.String(http.StatusOK, header.Get("X-Never"))
+			},
+			wantLevel:  "ERROR",
+			wantStack:  true,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			router := gin.New()
+			router.Use(Recovery(logger))
+			router.GET("/panic", tt.handler)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+
+			var entry map[string]any
+			if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+				t.Fatalf("decode log entry %q: %v", logs.String(), err)
+			}
+			if entry["level"] != tt.wantLevel {
+				t.Errorf("level = %v, want %s", entry["level"], tt.wantLevel)
+			}
+			stack, hasStack := entry["stack"].(string)
+			if hasStack != tt.wantStack {
+				t.Errorf("logged stack = %v, want %v", hasStack, tt.wantStack)
+			}
+			if tt.wantStack && !strings.Contains(stack, "goroutine") {
+				t.Errorf("stack = %q, want a goroutine trace", stack)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		header   string
+		wantKeep bool
+	}{
+		{"client ID", "req-42", true},
+		{"no ID", "", false},
+		{"too long", strings.Repeat("a", maxRequestIDLen+1), false},
+		{"control characters", "req\n42", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			router := gin.New()
+			router.Use(RequestID())
+			router.GET("/", func(c *gin.Context) { seen = GetRequestID(c) })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if seen == "" || rec.Header().Get(RequestIDHeader) != seen {
+				t.Fatalf("request ID = %q, response header %q, want the same non-empty ID", seen, rec.Header().Get(RequestIDHeader))
+			}
+			if kept := seen == tt.header; kept != tt.wantKeep {
+				t.Errorf("request ID = %q for header %q, want client ID kept %v", seen, tt.header, tt.wantKeep)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// grpcHealthWatchInterval is how often Watch streams re-evaluate readiness.
+const grpcHealthWatchInterval = time.Second
+
+// grpcHealthServer implements grpc.health.v1.Health on top of the same
+// readiness state served by the HTTP /ready endpoint. Only the server-wide
+// service name ("") is known.
+type grpcHealthServer struct {
+	healthpb.UnimplementedHealthServer
+	ready func() bool
+}
+
+// newGRPCHealthServer creates a health service reporting SERVING when ready returns true.
+func newGRPCHealthServer(ready func() bool) *grpcHealthServer {
+	return &grpcHealthServer{ready: ready}
+}
+
+// Check returns the current serving status.
+func (s *grpcHealthServer) Check(_ context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.GetService() != "" {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: s.status()}, nil
+}
+
+// List returns the status of every known service.
+func (s *grpcHealthServer) List(_ context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	return &healthpb.HealthListResponse{
+		Statuses: map[string]*healthpb.HealthCheckResponse{
+			"": {Status: s.status()},
+		},
+	}, nil
+}
+
+// Watch streams the serving status, sending an update whenever it changes.
+func (s *grpcHealthServer) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	if req.GetService() != "" {
+		return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVICE_UNKNOWN})
+	}
+
+	ticker := time.NewTicker(grpcHealthWatchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		if current := s.status(); current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// status maps readiness to a serving status.
+func (s *grpcHealthServer) status() healthpb.HealthCheckResponse_ServingStatus {
+	if s.ready() {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
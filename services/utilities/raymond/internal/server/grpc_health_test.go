@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/health"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// newHealthClient serves the gRPC health service for handler and returns a
+// client connected to it.
+func newHealthClient(t *testing.T, handler *health.Handler) healthpb.HealthClient {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, newGRPCHealthServer(handler.IsReady))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestGRPCHealthTracksReadiness(t *testing.T) {
+	handler := health.NewHandler(nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client := newHealthClient(t, handler)
+	ctx := context.Background()
+
+	check := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+		if resp.GetStatus() != want {
+			t.Errorf("status = %v, want %v", resp.GetStatus(), want)
+		}
+	}
+
+	check(healthpb.HealthCheckResponse_NOT_SERVING)
+	handler.SetReady(true)
+	check(healthpb.HealthCheckResponse_SERVING)
+	handler.SetMaintenance(true)
+	check(healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestGRPCHealthUnknownService(t *testing.T) {
+	handler := health.NewHandler(nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client := newHealthClient(t, handler)
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "billing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Check error code = %v, want %v", status.Code(err), codes.NotFound)
+	}
+}
+
+func TestGRPCHealthWatchSendsChanges(t *testing.T) {
+	handler := health.NewHandler(nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client := newHealthClient(t, handler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	recv := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if resp.GetStatus() != want {
+			t.Errorf("status = %v, want %v", resp.GetStatus(), want)
+		}
+	}
+
+	recv(healthpb.HealthCheckResponse_NOT_SERVING)
+	handler.SetReady(true)
+	recv(healthpb.HealthCheckResponse_SERVING)
+}
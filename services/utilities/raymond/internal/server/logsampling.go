@@ -0,0 +1,48 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// logSamplingRequest is the body of PUT /debug/logsampling: a rate between 0
+// and 1, or "all" to keep every record.
+type logSamplingRequest struct {
+	Rate json.RawMessage `json:"rate" binding:"required"`
+}
+
+// getLogSampling reports the current log sampling rate.
+func (s *Server) getLogSampling(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"rate": s.logSampler.Rate()})
+}
+
+// putLogSampling changes the log sampling rate. The new rate applies to the
+// next record logged.
+func (s *Server) putLogSampling(c *gin.Context) {
+	var req logSamplingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "body must be {\"rate\": <0-1 | \"all\">}"})
+		return
+	}
+
+	var rate float64
+	var all string
+	switch {
+	case json.Unmarshal(req.Rate, &all) == nil && all == "all":
+		rate = 1
+	case json.Unmarshal(req.Rate, &rate) == nil && rate >= 0 && rate <= 1:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rate must be between 0 and 1, or \"all\""})
+		return
+	}
+
+	previous := s.logSampler.Rate()
+	s.logSampler.SetRate(rate)
+	s.logger.Warn("log sampling rate changed",
+		"from", previous,
+		"to", rate,
+		"client_ip", c.ClientIP())
+	c.JSON(http.StatusOK, gin.H{"rate": rate})
+}
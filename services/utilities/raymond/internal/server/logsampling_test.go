@@ -0,0 +1,66 @@
+package server
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/health"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/telemetry"
+)
+
+func TestPutLogSamplingAllStopsSampling(t *testing.T) {
+	sampler := telemetry.NewLogSampler(0)
+	var logs bytes.Buffer
+	logger := slog.New(sampler.Handler(slog.NewTextHandler(&logs, nil)))
+	logBurst := func() int {
+		logs.Reset()
+		for range 100 {
+			logger.Info("dependency health check")
+		}
+		return strings.Count(logs.String(), "\n")
+	}
+
+	s := NewServer(&config.ServerConfig{AdminToken: "s3cret"}, discardLogger(), nil, health.NewHandler(nil, discardLogger()), nil, nil)
+	s.SetLogSampler(sampler)
+	router := newTestRouter(s)
+	put := func(token, body string) int {
+		req := httptest.NewRequest(http.MethodPut, "/debug/logsampling", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if kept := logBurst(); kept != 0 {
+		t.Fatalf("kept %d of 100 records at rate 0, want 0", kept)
+	}
+
+	// Rejected changes leave the rate alone
+	if code := put("", `{"rate":"all"}`); code != http.StatusUnauthorized {
+		t.Errorf("PUT without token = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := put("s3cret", `{"rate":2}`); code != http.StatusBadRequest {
+		t.Errorf("PUT rate 2 = %d, want %d", code, http.StatusBadRequest)
+	}
+	if kept := logBurst(); kept != 0 {
+		t.Errorf("kept %d of 100 records after rejected changes, want 0", kept)
+	}
+
+	if code := put("s3cret", `{"rate":"all"}`); code != http.StatusOK {
+		t.Fatalf("PUT rate all = %d, want %d", code, http.StatusOK)
+	}
+	if kept := logBurst(); kept != 100 {
+		t.Errorf("kept %d of 100 records at rate all, want 100", kept)
+	}
+	if rate := sampler.Rate(); rate != 1 {
+		t.Errorf("sampler rate = %v, want 1", rate)
+	}
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// openAPISpec builds the OpenAPI 3 description of the health and debug
+// endpoints. healthPrefix is the configured health_path_prefix.
+func openAPISpec(healthPrefix string) gin.H {
+	jsonResponse := func(description, schemaRef string) gin.H {
+		return gin.H{
+			"description": description,
+			"content": gin.H{
+				"application/json": gin.H{
+					"schema": gin.H{"$ref": "#/components/schemas/" + schemaRef},
+				},
+			},
+		}
+	}
+	textResponse := func(description string) gin.H {
+		return gin.H{
+			"description": description,
+			"content": gin.H{
+				"text/plain": gin.H{"schema": gin.H{"type": "string"}},
+			},
+		}
+	}
+
+	return gin.H{
+		"openapi": "3.0.3",
+		"info": gin.H{
+			"title":   "arc-raymond-bootstrap",
+			"version": "1.0.0",
+		},
+		"paths": gin.H{
+			healthPrefix + "/health": gin.H{
+				"get": gin.H{
+					"summary": "Shallow health check (process alive)",
+					"responses": gin.H{
+						"200": jsonResponse("Service is alive", "ShallowHealthResponse"),
+					},
+				},
+			},
+			healthPrefix + "/health/deep": gin.H{
+				"get": gin.H{
+					"summary": "Probe every configured dependency",
+					"responses": gin.H{
+						"200": jsonResponse("All dependencies healthy, initializing or served from cache", "DeepHealthResponse"),
+						"503": jsonResponse("At least one dependency is unhealthy, or maintenance mode is on", "DeepHealthResponse"),
+					},
+				},
+			},
+			healthPrefix + "/health/dependencies": gin.H{
+				"get": gin.H{
+					"summary": "Latest background monitor results, without probing",
+					"responses": gin.H{
+						"200": jsonResponse("All dependencies healthy or initializing at the last check", "MonitoredDependenciesResponse"),
+						"503": jsonResponse("At least one dependency was unhealthy at the last check, or none has run yet", "MonitoredDependenciesResponse"),
+					},
+				},
+			},
+			healthPrefix + "/ready": gin.H{
+				"get": gin.H{
+					"summary": "Readiness probe",
+					"responses": gin.H{
+						"200": jsonResponse("Service is ready", "ReadyResponse"),
+						"503": jsonResponse("Service is not ready", "ReadyResponse"),
+					},
+				},
+			},
+			healthPrefix + "/livez": gin.H{
+				"get": gin.H{
+					"summary": "Liveness probe; never checks dependencies",
+					"responses": gin.H{
+						"200": textResponse("Process is alive (\"ok\")"),
+					},
+				},
+			},
+			healthPrefix + "/readyz": gin.H{
+				"get": gin.H{
+					"summary": "Readiness probe with a plain-text body",
+					"responses": gin.H{
+						"200": textResponse("Service is ready (\"ok\")"),
+						"503": textResponse("Service is not ready (\"not ready: <reason>\")"),
+					},
+				},
+			},
+			"/bootstrap/status": gin.H{
+				"get": gin.H{
+					"summary": "Per-phase bootstrap progress",
+					"responses": gin.H{
+						"200": gin.H{"description": "Bootstrap status snapshot"},
+					},
+				},
+			},
+			"/admin/maintenance": gin.H{
+				"post": gin.H{
+					"summary":  "Enable maintenance mode (not ready, deep health reports maintenance)",
+					"security": []gin.H{{"bearerAuth": []string{}}},
+					"responses": gin.H{
+						"200": gin.H{"description": "Maintenance mode enabled"},
+						"401": gin.H{"description": "Missing or wrong admin token"},
+					},
+				},
+				"delete": gin.H{
+					"summary":  "Disable maintenance mode",
+					"security": []gin.H{{"bearerAuth": []string{}}},
+					"responses": gin.H{
+						"200": gin.H{"description": "Maintenance mode disabled"},
+						"401": gin.H{"description": "Missing or wrong admin token"},
+					},
+				},
+			},
+			"/debug/logsampling": gin.H{
+				"get": gin.H{
+					"summary":  "Current fraction of debug/info log records kept",
+					"security": []gin.H{{"bearerAuth": []string{}}},
+					"responses": gin.H{
+						"200": gin.H{"description": "Sampling rate"},
+						"401": gin.H{"description": "Missing or wrong admin token"},
+					},
+				},
+				"put": gin.H{
+					"summary":  "Change the log sampling rate; {\"rate\": 0-1 or \"all\"}",
+					"security": []gin.H{{"bearerAuth": []string{}}},
+					"responses": gin.H{
+						"200": gin.H{"description": "Sampling rate changed"},
+						"400": gin.H{"description": "Rate missing or out of range"},
+						"401": gin.H{"description": "Missing or wrong admin token"},
+					},
+				},
+			},
+			"/debug/breakers": gin.H{
+				"get": gin.H{
+					"summary": "Circuit breaker state of each bootstrap client",
+					"responses": gin.H{
+						"200": jsonResponse("Breaker states", "BreakersResponse"),
+					},
+				},
+			},
+		},
+		"components": gin.H{
+			"securitySchemes": gin.H{
+				"bearerAuth": gin.H{"type": "http", "scheme": "bearer"},
+			},
+			"schemas": gin.H{
+				"ShallowHealthResponse": gin.H{
+					"type": "object",
+					"properties": gin.H{
+						"status": gin.H{"type": "string"},
+						"mode":   gin.H{"type": "string", "enum": []string{"shallow"}},
+					},
+				},
+				"DeepHealthResponse": gin.H{
+					"type":     "object",
+					"required": []string{"status", "mode", "stale", "dependencies"},
+					"properties": gin.H{
+						"status": gin.H{
+							"type": "string",
+							"enum": []string{"healthy", "unhealthy", "degraded", "initializing", "maintenance"},
+						},
+						"mode":  gin.H{"type": "string", "enum": []string{"deep"}},
+						"stale": gin.H{"type": "boolean"},
+						"dependencies": gin.H{
+							"type":                 "object",
+							"additionalProperties": gin.H{"$ref": "#/components/schemas/ProbeResult"},
+						},
+					},
+				},
+				"MonitoredDependenciesResponse": gin.H{
+					"type":     "object",
+					"required": []string{"status", "mode"},
+					"properties": gin.H{
+						"status": gin.H{
+							"type": "string",
+							"enum": []string{"healthy", "unhealthy", "initializing"},
+						},
+						"mode":        gin.H{"type": "string", "enum": []string{"monitored"}},
+						"message":     gin.H{"type": "string"},
+						"checked_at":  gin.H{"type": "string", "format": "date-time"},
+						"age_seconds": gin.H{"type": "number"},
+						"dependencies": gin.H{
+							"type":                 "object",
+							"additionalProperties": gin.H{"$ref": "#/components/schemas/ProbeResult"},
+						},
+					},
+				},
+				"ProbeResult": gin.H{
+					"type": "object",
+					"properties": gin.H{
+						"Name":   gin.H{"type": "string"},
+						"Type":   gin.H{"type": "string"},
+						"Target": gin.H{"type": "string"},
+						"OK":     gin.H{"type": "boolean"},
+						"Status": gin.H{
+							"type": "string",
+							"enum": []string{"healthy", "unhealthy", "degraded", "sidecar_not_ready", "initializing", "probe_error"},
+						},
+						"LatencyMS": gin.H{"type": "integer", "format": "int64"},
+						"Error":     gin.H{"type": "string"},
+						"Stale":     gin.H{"type": "boolean"},
+						"Timing": gin.H{
+							"type": "object",
+							"properties": gin.H{
+								"DNSMS":          gin.H{"type": "integer", "format": "int64"},
+								"ConnectMS":      gin.H{"type": "integer", "format": "int64"},
+								"TLSHandshakeMS": gin.H{"type": "integer", "format": "int64"},
+								"FirstByteMS":    gin.H{"type": "integer", "format": "int64"},
+							},
+						},
+						"Targets": gin.H{
+							"type": "array",
+							"items": gin.H{
+								"type": "object",
+								"properties": gin.H{
+									"Target":    gin.H{"type": "string"},
+									"OK":        gin.H{"type": "boolean"},
+									"LatencyMS": gin.H{"type": "integer", "format": "int64"},
+									"Error":     gin.H{"type": "string"},
+								},
+							},
+						},
+					},
+				},
+				"ReadyResponse": gin.H{
+					"type": "object",
+					"properties": gin.H{
+						"ready":   gin.H{"type": "boolean"},
+						"message": gin.H{"type": "string"},
+						"failing_dependencies": gin.H{
+							"type":  "array",
+							"items": gin.H{"type": "string"},
+						},
+						"reasons": gin.H{
+							"type":        "array",
+							"items":       gin.H{"type": "string"},
+							"description": "Everything blocking readiness, e.g. \"phase initialize_nats is running\"",
+						},
+					},
+				},
+				"BreakersResponse": gin.H{
+					"type": "object",
+					"properties": gin.H{
+						"breakers": gin.H{
+							"type": "array",
+							"items": gin.H{
+								"type": "object",
+								"properties": gin.H{
+									"name":                 gin.H{"type": "string"},
+									"state":                gin.H{"type": "string", "enum": []string{"closed", "half-open", "open"}},
+									"requests":             gin.H{"type": "integer"},
+									"total_failures":       gin.H{"type": "integer"},
+									"consecutive_failures": gin.H{"type": "integer"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
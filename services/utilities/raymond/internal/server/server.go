@@ -4,53 +4,114 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"sync"
 
+	"github.com/arc-framework/platform-spike/services/raymond/internal/bootstrap"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/clients"
 	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
 	"github.com/arc-framework/platform-spike/services/raymond/internal/health"
 	"github.com/arc-framework/platform-spike/services/raymond/internal/middleware"
 	"github.com/arc-framework/platform-spike/services/raymond/internal/telemetry"
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // Server manages the HTTP server lifecycle.
 type Server struct {
-	cfg           *config.ServerConfig
-	logger        *slog.Logger
-	metrics       *telemetry.Metrics
-	healthHandler *health.Handler
-	httpServer    *http.Server
+	cfg            *config.ServerConfig
+	logger         *slog.Logger
+	metrics        *telemetry.Metrics
+	healthHandler  *health.Handler
+	status         *bootstrap.Status
+	breakers       *clients.BreakerRegistry
+	metricsPath    string
+	metricsHandler http.Handler
+	logSampler     *telemetry.LogSampler
+
+	// mu guards the servers, which Start creates and Shutdown may stop from
+	// another goroutine at any point, even before Start runs
+	mu           sync.Mutex
+	httpServer   *http.Server
+	grpcServer   *grpc.Server
+	shuttingDown bool
 }
 
-// NewServer creates a new HTTP server.
+// NewServer creates a new HTTP server. status and breakers may be nil, in
+// which case the bootstrap status and breaker endpoints are not registered.
 func NewServer(
 	cfg *config.ServerConfig,
 	logger *slog.Logger,
 	metrics *telemetry.Metrics,
 	healthHandler *health.Handler,
+	status *bootstrap.Status,
+	breakers *clients.BreakerRegistry,
 ) *Server {
 	return &Server{
 		cfg:           cfg,
 		logger:        logger,
 		metrics:       metrics,
 		healthHandler: healthHandler,
+		status:        status,
+		breakers:      breakers,
 	}
 }
 
+// SetMetricsHandler serves h at path, typically the telemetry provider's
+// Prometheus handler. A nil h registers nothing. It must be called before
+// Start.
+func (s *Server) SetMetricsHandler(path string, h http.Handler) {
+	s.metricsPath = path
+	s.metricsHandler = h
+}
+
+// SetLogSampler exposes sampler's rate at /debug/logsampling, behind the
+// admin token. It must be called before Start.
+func (s *Server) SetLogSampler(sampler *telemetry.LogSampler) {
+	s.logSampler = sampler
+}
+
 // Start initializes and starts the HTTP server.
-// This method blocks until the server is shut down.
+// This method blocks until the server is shut down. It returns nil right away
+// if Shutdown was already called.
 func (s *Server) Start() error {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
+	// Answer known paths with the wrong method with 405 instead of 404
+	router.HandleMethodNotAllowed = true
 
 	// Middleware chain (order matters!)
+	router.Use(middleware.RequestID())
 	router.Use(middleware.Recovery(s.logger))
 	router.Use(otelgin.Middleware("arc-raymond-bootstrap"))
 	router.Use(middleware.RequestLogger(s.logger, s.metrics))
 
 	// Register routes
 	s.registerRoutes(router)
+	router.NoRoute(func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":      "not found",
+			"path":       c.Request.URL.Path,
+			"request_id": middleware.GetRequestID(c),
+		})
+	})
+	router.NoMethod(func(c *gin.Context) {
+		c.JSON(http.StatusMethodNotAllowed, gin.H{
+			"error":      "method not allowed",
+			"method":     c.Request.Method,
+			"path":       c.Request.URL.Path,
+			"request_id": middleware.GetRequestID(c),
+		})
+	})
+
+	s.mu.Lock()
+	if s.shuttingDown {
+		s.mu.Unlock()
+		return nil
+	}
 
 	// Create HTTP server
 	s.httpServer = &http.Server{
@@ -58,12 +119,25 @@ func (s *Server) Start() error {
 		Handler:      router,
 		ReadTimeout:  s.cfg.ReadTimeout,
 		WriteTimeout: s.cfg.WriteTimeout,
+		// Requests with larger headers get 431; 0 keeps net/http's 1 MB
+		MaxHeaderBytes: s.cfg.MaxHeaderBytes,
 	}
 
+	httpServer := s.httpServer
+
+	if s.cfg.GRPCHealthPort != 0 {
+		if err := s.startGRPCHealth(); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	}
+	s.mu.Unlock()
+
 	s.logger.Info("starting HTTP server", "port", s.cfg.Port)
 
-	// Start server (blocks until shutdown)
-	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	// Start server (blocks until shutdown). A Shutdown that lands before
+	// this makes it return ErrServerClosed at once.
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		s.logger.Error("HTTP server failed to start", "error", err)
 		return fmt.Errorf("http server: %w", err)
 	}
@@ -71,18 +145,101 @@ func (s *Server) Start() error {
 	return nil
 }
 
-// Shutdown gracefully shuts down the HTTP server.
+// startGRPCHealth starts the gRPC health service in the background. Callers
+// must hold s.mu.
+func (s *Server) startGRPCHealth() error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.cfg.GRPCHealthPort))
+	if err != nil {
+		return fmt.Errorf("grpc health listen: %w", err)
+	}
+
+	s.grpcServer = grpc.NewServer()
+	healthpb.RegisterHealthServer(s.grpcServer, newGRPCHealthServer(s.healthHandler.IsReady))
+
+	s.logger.Info("starting gRPC health server", "port", s.cfg.GRPCHealthPort)
+	go func() {
+		if err := s.grpcServer.Serve(lis); err != nil {
+			s.logger.Error("gRPC health server failed", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown gracefully shuts down the HTTP server. It is safe to call before
+// or while Start runs; a later Start doesn't serve.
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.shuttingDown = true
+	httpServer, grpcServer := s.httpServer, s.grpcServer
+	s.mu.Unlock()
+
+	if grpcServer != nil {
+		s.logger.Info("shutting down gRPC health server")
+		grpcServer.GracefulStop()
+	}
+
+	if httpServer == nil {
+		return nil
+	}
 	s.logger.Info("shutting down HTTP server")
-	return s.httpServer.Shutdown(ctx)
+	return httpServer.Shutdown(ctx)
 }
 
 // registerRoutes sets up all HTTP routes.
 func (s *Server) registerRoutes(router *gin.Engine) {
-	// Health endpoints
-	router.GET("/health", s.healthHandler.HealthHandler)
-	router.GET("/health/deep", s.healthHandler.DeepHealthHandler)
-	router.GET("/ready", s.healthHandler.ReadyHandler)
+	// Health endpoints, optionally mounted under a prefix such as /internal
+	health := router.Group(s.cfg.HealthPathPrefix)
+	if s.cfg.EnableCompression {
+		// Deep health with many dependencies is large and polled often
+		health.Use(middleware.Gzip(s.cfg.CompressionMinSize))
+	}
+	health.GET("/health", s.healthHandler.HealthHandler)
+	health.GET("/health/deep", s.healthHandler.DeepHealthHandler)
+	health.GET("/health/dependencies", s.healthHandler.DependenciesHandler)
+	health.GET("/ready", s.healthHandler.ReadyHandler)
+	// Kubernetes-style probes with cheap plain-text bodies
+	health.GET("/livez", s.healthHandler.LivezHandler)
+	health.GET("/readyz", s.healthHandler.ReadyzHandler)
+
+	// Bootstrap progress
+	if s.status != nil {
+		router.GET("/bootstrap/status", func(c *gin.Context) {
+			c.JSON(http.StatusOK, s.status.Snapshot())
+		})
+	}
+
+	// Circuit breaker states of the bootstrap clients
+	if s.breakers != nil {
+		router.GET("/debug/breakers", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"breakers": s.breakers.Snapshot()})
+		})
+	}
+
+	// Prometheus scrape endpoint
+	if s.metricsHandler != nil {
+		router.GET(s.metricsPath, gin.WrapH(s.metricsHandler))
+	}
+
+	// Maintenance mode, only when an admin token is configured
+	if s.cfg.AdminToken != "" {
+		admin := router.Group("/admin", middleware.AdminAuth(s.cfg.AdminToken))
+		admin.POST("/maintenance", s.healthHandler.MaintenanceOnHandler)
+		admin.DELETE("/maintenance", s.healthHandler.MaintenanceOffHandler)
+
+		// Log sampling rate, adjustable during an incident
+		if s.logSampler != nil {
+			logSampling := router.Group("/debug/logsampling", middleware.AdminAuth(s.cfg.AdminToken))
+			logSampling.GET("", s.getLogSampling)
+			logSampling.PUT("", s.putLogSampling)
+		}
+	}
+
+	// Machine-readable description of the endpoints above
+	spec := openAPISpec(s.cfg.HealthPathPrefix)
+	router.GET("/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, spec)
+	})
 
 	// Root endpoint
 	router.GET("/", func(c *gin.Context) {
@@ -0,0 +1,456 @@
+package server
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/bootstrap"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/clients"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/health"
+	"github.com/gin-gonic/gin"
+)
+
+// discardLogger returns a logger that drops everything.
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// newTestRouter returns a router with s's routes registered.
+func newTestRouter(s *Server) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	s.registerRoutes(router)
+	return router
+}
+
+// serve sends one request through router and returns the recorded response.
+func serve(router http.Handler, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestHealthPathPrefix(t *testing.T) {
+	checker := health.NewChecker(nil, discardLogger(), nil, time.Second)
+	handler := health.NewHandler(checker, discardLogger())
+	handler.SetReady(true)
+	cfg := &config.ServerConfig{HealthPathPrefix: "/internal"}
+	router := newTestRouter(NewServer(cfg, discardLogger(), nil, handler, nil, nil))
+
+	for _, path := range []string{"/health", "/health/deep", "/ready"} {
+		if rec := serve(router, http.MethodGet, "/internal"+path); rec.Code != http.StatusOK {
+			t.Errorf("GET /internal%s = %d, want %d", path, rec.Code, http.StatusOK)
+		}
+		if rec := serve(router, http.MethodGet, path); rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want %d", path, rec.Code, http.StatusNotFound)
+		}
+	}
+}
+
+func TestBreakersEndpointReportsOpenBreaker(t *testing.T) {
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer admin.Close()
+
+	pulsar, err := clients.NewPulsarClient(context.Background(), config.PulsarConfig{
+		AdminURL:   admin.URL,
+		ServiceURL: "pulsar://127.0.0.1:6650",
+		Tenant:     "arc",
+		Namespaces: []string{"events"},
+	})
+	if err != nil {
+		t.Fatalf("NewPulsarClient: %v", err)
+	}
+	defer pulsar.Close()
+
+	// gobreaker trips after more than 5 consecutive failures
+	for range 6 {
+		if err := pulsar.CreateNamespace(context.Background(), "events"); err == nil {
+			t.Fatal("CreateNamespace succeeded against a failing admin API")
+		}
+	}
+
+	breakers := clients.NewBreakerRegistry()
+	breakers.Register("pulsar", pulsar)
+	router := newTestRouter(NewServer(&config.ServerConfig{}, discardLogger(), nil, health.NewHandler(nil, discardLogger()), nil, breakers))
+
+	rec := serve(router, http.MethodGet, "/debug/breakers")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /debug/breakers = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var body struct {
+		Breakers []clients.BreakerState `json:"breakers"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body %q: %v", rec.Body.String(), err)
+	}
+	if len(body.Breakers) != 1 || body.Breakers[0].Name != "pulsar" || body.Breakers[0].State != "open" {
+		t.Errorf("breakers = %+v, want a single open pulsar breaker", body.Breakers)
+	}
+}
+
+// openAPIDocument is the part of the OpenAPI spec the tests inspect.
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi"`
+	Paths   map[string]map[string]struct {
+		Responses map[string]struct {
+			Content map[string]struct {
+				Schema struct {
+					Ref string `json:"$ref"`
+				} `json:"schema"`
+			} `json:"content"`
+		} `json:"responses"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func TestOpenAPIDescribesDeepHealth(t *testing.T) {
+	cfg := &config.ServerConfig{HealthPathPrefix: "/internal"}
+	router := newTestRouter(NewServer(cfg, discardLogger(), nil, health.NewHandler(nil, discardLogger()), nil, nil))
+
+	rec := serve(router, http.MethodGet, "/openapi.json")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var doc openAPIDocument
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("openapi = %q, want 3.x", doc.OpenAPI)
+	}
+
+	deep, ok := doc.Paths["/internal/health/deep"]["get"]
+	if !ok {
+		t.Fatalf("no GET /internal/health/deep in paths %v", doc.Paths)
+	}
+	ref := deep.Responses["200"].Content["application/json"].Schema.Ref
+	if ref != "#/components/schemas/DeepHealthResponse" {
+		t.Errorf("deep health 200 schema = %q, want the DeepHealthResponse schema", ref)
+	}
+
+	// The schema documents every field the handler actually returns
+	schema, ok := doc.Components.Schemas["DeepHealthResponse"]
+	if !ok {
+		t.Fatal("no DeepHealthResponse schema")
+	}
+	fields := reflect.TypeFor[health.DeepHealthResponse]()
+	for i := range fields.NumField() {
+		name, _, _ := strings.Cut(fields.Field(i).Tag.Get("json"), ",")
+		if _, ok := schema.Properties[name]; !ok {
+			t.Errorf("DeepHealthResponse schema has no %q property", name)
+		}
+	}
+}
+
+func TestDeepHealthGzip(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer api.Close()
+	var deps []config.DependencyConfig
+	for i := range 40 {
+		deps = append(deps, config.DependencyConfig{Name: fmt.Sprintf("service-%02d", i), Type: "http", URL: api.URL})
+	}
+	checker := health.NewChecker(deps, discardLogger(), nil, time.Second)
+	cfg := &config.ServerConfig{EnableCompression: true, CompressionMinSize: 1024}
+	router := newTestRouter(NewServer(cfg, discardLogger(), nil, health.NewHandler(checker, discardLogger()), nil, nil))
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantGzip       bool
+	}{
+		{"large body, gzip accepted", "/health/deep", "gzip, deflate", true},
+		{"large body, gzip refused", "/health/deep", "gzip;q=0", false},
+		{"large body, no Accept-Encoding", "/health/deep", "", false},
+		{"small body", "/health", "gzip", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("GET %s = %d, want %d", tt.path, rec.Code, http.StatusOK)
+			}
+			gzipped := rec.Header().Get("Content-Encoding") == "gzip"
+			if gzipped != tt.wantGzip {
+				t.Fatalf("gzip encoded = %v, want %v", gzipped, tt.wantGzip)
+			}
+
+			var body io.Reader = rec.Body
+			if gzipped {
+				gz, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("gzip.NewReader: %v", err)
+				}
+				body = gz
+			}
+			var decoded map[string]any
+			if err := json.NewDecoder(body).Decode(&decoded); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if tt.path == "/health/deep" {
+				if n := len(decoded["dependencies"].(map[string]any)); n != len(deps) {
+					t.Errorf("dependencies = %d, want %d", n, len(deps))
+				}
+			}
+		})
+	}
+}
+
+func TestMaintenanceModeToggle(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer api.Close()
+	checker := health.NewChecker([]config.DependencyConfig{{Name: "api", Type: "http", URL: api.URL}}, discardLogger(), nil, time.Second)
+	handler := health.NewHandler(checker, discardLogger())
+	handler.SetReady(true)
+	cfg := &config.ServerConfig{AdminToken: "s3cret"}
+	router := newTestRouter(NewServer(cfg, discardLogger(), nil, handler, nil, nil))
+
+	admin := func(method, token string) int {
+		req := httptest.NewRequest(method, "/admin/maintenance", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	expect := func(readyCode int, deepStatus string) {
+		t.Helper()
+		if rec := serve(router, http.MethodGet, "/ready"); rec.Code != readyCode {
+			t.Errorf("GET /ready = %d, want %d", rec.Code, readyCode)
+		}
+		var deep health.DeepHealthResponse
+		rec := serve(router, http.MethodGet, "/health/deep")
+		if err := json.Unmarshal(rec.Body.Bytes(), &deep); err != nil {
+			t.Fatalf("decode /health/deep body %q: %v", rec.Body.String(), err)
+		}
+		if deep.Status != deepStatus {
+			t.Errorf("/health/deep status = %q, want %q", deep.Status, deepStatus)
+		}
+	}
+
+	expect(http.StatusOK, "healthy")
+
+	for _, token := range []string{"", "wrong"} {
+		if code := admin(http.MethodPost, token); code != http.StatusUnauthorized {
+			t.Errorf("POST /admin/maintenance with token %q = %d, want %d", token, code, http.StatusUnauthorized)
+		}
+	}
+	expect(http.StatusOK, "healthy")
+
+	if code := admin(http.MethodPost, "s3cret"); code != http.StatusOK {
+		t.Fatalf("POST /admin/maintenance = %d, want %d", code, http.StatusOK)
+	}
+	expect(http.StatusServiceUnavailable, "maintenance")
+
+	if code := admin(http.MethodDelete, "s3cret"); code != http.StatusOK {
+		t.Fatalf("DELETE /admin/maintenance = %d, want %d", code, http.StatusOK)
+	}
+	expect(http.StatusOK, "healthy")
+}
+
+func TestMaintenanceEndpointsNeedAdminToken(t *testing.T) {
+	router := newTestRouter(NewServer(&config.ServerConfig{}, discardLogger(), nil, health.NewHandler(nil, discardLogger()), nil, nil))
+	if rec := serve(router, http.MethodPost, "/admin/maintenance"); rec.Code != http.StatusNotFound {
+		t.Errorf("POST /admin/maintenance without admin_token configured = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+// startServer runs s on a free port until the test ends and returns its base
+// URL once it accepts requests.
+func startServer(t *testing.T, s *Server) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s.cfg.Port = lis.Addr().(*net.TCPAddr).Port
+	lis.Close()
+
+	go s.Start()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		s.Shutdown(ctx)
+	})
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", s.cfg.Port)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := http.Get(url + "/health")
+		if err == nil {
+			resp.Body.Close()
+			return url
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server did not start: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMaxHeaderBytes(t *testing.T) {
+	const maxHeaderBytes = 8 << 10
+	cfg := &config.ServerConfig{MaxHeaderBytes: maxHeaderBytes}
+	url := startServer(t, NewServer(cfg, discardLogger(), nil, health.NewHandler(nil, discardLogger()), nil, nil))
+
+	// net/http reads up to 4096 bytes past MaxHeaderBytes before rejecting
+	// the request, so "over" has to clear that slack. Reused connections
+	// allow a little more, so each request gets a new one
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	tests := []struct {
+		name      string
+		headerLen int
+		want      int
+	}{
+		{"just under the limit", maxHeaderBytes - 512, http.StatusOK},
+		{"over the limit", maxHeaderBytes + 4096 + 512, http.StatusRequestHeaderFieldsTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, url+"/health", nil)
+			if err != nil {
+				t.Fatalf("new request: %v", err)
+			}
+			req.Header.Set("X-Forwarded-For", strings.Repeat("a", tt.headerLen))
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("GET /health: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("GET /health with a %d byte header = %d, want %d", tt.headerLen, resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadyListsBlockingReasons(t *testing.T) {
+	postgres := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer postgres.Close()
+	deps := []config.DependencyConfig{{Name: "postgres", Type: "http", URL: postgres.URL, Critical: true}}
+	checker := health.NewChecker(deps, discardLogger(), nil, time.Second)
+	checker.RunAll(context.Background())
+
+	status := bootstrap.NewStatus("run-1")
+	status.Register("initialize_nats", true)
+	status.Register("warm_cache", false)
+	status.MarkRunning("initialize_nats")
+
+	handler := health.NewHandler(checker, discardLogger())
+	handler.SetRequireCriticalDependencies(true)
+	handler.AddBlockingReasons(status.BlockingPhases)
+	router := newTestRouter(NewServer(&config.ServerConfig{}, discardLogger(), nil, handler, status, nil))
+
+	rec := serve(router, http.MethodGet, "/ready")
+	var body struct {
+		Ready   bool     `json:"ready"`
+		Reasons []string `json:"reasons"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode /ready body %q: %v", rec.Body.String(), err)
+	}
+	if rec.Code != http.StatusServiceUnavailable || body.Ready {
+		t.Errorf("GET /ready = %d ready %v, want 503 not ready", rec.Code, body.Ready)
+	}
+	want := []string{
+		"bootstrap not complete",
+		"phase initialize_nats is running",
+		"critical dependency postgres unhealthy",
+	}
+	if !reflect.DeepEqual(body.Reasons, want) {
+		t.Errorf("reasons = %q, want %q", body.Reasons, want)
+	}
+}
+
+func TestUnknownRoutesReturnJSON(t *testing.T) {
+	url := startServer(t, NewServer(&config.ServerConfig{}, discardLogger(), nil, health.NewHandler(nil, discardLogger()), nil, nil))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantError  string
+	}{
+		{"unknown path", http.MethodGet, "/nope", http.StatusNotFound, "not found"},
+		{"wrong method", http.MethodPost, "/health", http.StatusMethodNotAllowed, "method not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, url+tt.path, nil)
+			if err != nil {
+				t.Fatalf("new request: %v", err)
+			}
+			req.Header.Set("X-Request-ID", "req-42")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("%s %s: %v", tt.method, tt.path, err)
+			}
+			defer resp.Body.Close()
+
+			var body struct {
+				Error     string `json:"error"`
+				Path      string `json:"path"`
+				RequestID string `json:"request_id"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode %s %s body: %v", tt.method, tt.path, err)
+			}
+			if resp.StatusCode != tt.wantStatus || body.Error != tt.wantError || body.Path != tt.path {
+				t.Errorf("%s %s = %d %+v, want %d %q for %s", tt.method, tt.path, resp.StatusCode, body, tt.wantStatus, tt.wantError, tt.path)
+			}
+			if body.RequestID != "req-42" || resp.Header.Get("X-Request-ID") != "req-42" {
+				t.Errorf("request ID in body, header = %q, %q, want req-42", body.RequestID, resp.Header.Get("X-Request-ID"))
+			}
+		})
+	}
+}
+
+func TestShutdownBeforeStart(t *testing.T) {
+	s := NewServer(&config.ServerConfig{}, discardLogger(), nil, health.NewHandler(nil, discardLogger()), nil, nil)
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown before Start: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- s.Start() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Start after Shutdown = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start served after Shutdown")
+	}
+}
@@ -0,0 +1,138 @@
+package supervisor
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Heartbeat records when a worker last completed a cycle of its loop.
+type Heartbeat struct {
+	last atomic.Int64
+}
+
+// Beat marks the worker as alive. Workers call it once per loop iteration.
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+// since returns how long ago the last beat happened.
+func (h *Heartbeat) since() time.Duration {
+	return time.Since(time.Unix(0, h.last.Load()))
+}
+
+// WorkerFunc is a background loop that calls hb.Beat every cycle and returns
+// when ctx is canceled.
+type WorkerFunc func(ctx context.Context, hb *Heartbeat)
+
+type worker struct {
+	parent   context.Context
+	name     string
+	run      WorkerFunc
+	hb       *Heartbeat
+	cancel   context.CancelFunc
+	restarts int
+}
+
+// Supervisor restarts background workers whose heartbeat goes stale. A stuck
+// goroutine can't be killed, so a restart cancels its context and starts a
+// fresh instance alongside it; a worker that eventually unblocks sees the
+// canceled context and exits.
+type Supervisor struct {
+	logger    *slog.Logger
+	threshold time.Duration
+
+	mu      sync.Mutex
+	workers []*worker
+}
+
+// New creates a supervisor that restarts workers which have not beaten for
+// longer than threshold. A threshold of 0 disables restarts; workers are still
+// started by Go.
+func New(logger *slog.Logger, threshold time.Duration) *Supervisor {
+	return &Supervisor{logger: logger, threshold: threshold}
+}
+
+// Go starts run under supervision. Workers stop when ctx is canceled.
+func (s *Supervisor) Go(ctx context.Context, name string, run WorkerFunc) {
+	w := &worker{parent: ctx, name: name, run: run}
+
+	s.mu.Lock()
+	s.workers = append(s.workers, w)
+	s.start(w)
+	s.mu.Unlock()
+}
+
+// Run checks worker heartbeats until ctx is canceled. It returns immediately
+// when restarts are disabled.
+func (s *Supervisor) Run(ctx context.Context) {
+	if s.threshold <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.threshold / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.restartStalled()
+		}
+	}
+}
+
+// Restarts returns how many times the named worker has been restarted.
+func (s *Supervisor) Restarts(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total := 0
+	for _, w := range s.workers {
+		if w.name == name {
+			total += w.restarts
+		}
+	}
+	return total
+}
+
+// restartStalled restarts every worker whose last beat is older than the
+// threshold.
+func (s *Supervisor) restartStalled() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, w := range s.workers {
+		if w.parent.Err() != nil {
+			continue
+		}
+
+		stale := w.hb.since()
+		if stale <= s.threshold {
+			continue
+		}
+
+		w.restarts++
+		s.logger.Warn("background worker stalled, restarting",
+			"worker", w.name,
+			"since_last_heartbeat", stale.String(),
+			"restarts", w.restarts)
+
+		w.cancel()
+		s.start(w)
+	}
+}
+
+// start launches a new instance of w with a fresh heartbeat. Callers hold mu.
+func (s *Supervisor) start(w *worker) {
+	ctx, cancel := context.WithCancel(w.parent)
+	hb := &Heartbeat{}
+	hb.Beat()
+
+	w.hb = hb
+	w.cancel = cancel
+	go w.run(ctx, hb)
+}
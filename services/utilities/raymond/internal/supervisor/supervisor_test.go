@@ -0,0 +1,84 @@
+package supervisor
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// discardLogger returns a logger that drops everything.
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestSupervisorRestartsStalledWorker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := New(discardLogger(), 50*time.Millisecond)
+	go s.Run(ctx)
+
+	// The first instance deadlocks after one cycle; later ones keep beating
+	var starts atomic.Int64
+	stalledCanceled := make(chan struct{})
+	s.Go(ctx, "monitor", func(ctx context.Context, hb *Heartbeat) {
+		if starts.Add(1) == 1 {
+			hb.Beat()
+			<-ctx.Done()
+			close(stalledCanceled)
+			return
+		}
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				hb.Beat()
+			}
+		}
+	})
+
+	select {
+	case <-stalledCanceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stalled worker was never restarted")
+	}
+
+	// The replacement beats, so it is left alone
+	time.Sleep(200 * time.Millisecond)
+	if got := s.Restarts("monitor"); got != 1 {
+		t.Errorf("Restarts = %d, want 1", got)
+	}
+	if got := starts.Load(); got != 2 {
+		t.Errorf("worker started %d times, want 2", got)
+	}
+}
+
+func TestSupervisorDisabled(t *testing.T) {
+	s := New(discardLogger(), 0)
+
+	done := make(chan struct{})
+	go func() {
+		s.Run(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run with restarts disabled did not return")
+	}
+
+	// Workers still start, they are just never restarted
+	started := make(chan struct{})
+	s.Go(context.Background(), "monitor", func(ctx context.Context, hb *Heartbeat) { close(started) })
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("worker not started")
+	}
+}
@@ -0,0 +1,26 @@
+package telemetry
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// mainModuleVersionKey carries the version of the binary's main module.
+const mainModuleVersionKey = attribute.Key("service.build.mainmodule.version")
+
+// BuildInfoAttributes returns resource attributes describing how the binary
+// was built: the Go runtime version and the main module's version.
+func BuildInfoAttributes() []attribute.KeyValue {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return []attribute.KeyValue{semconv.ProcessRuntimeVersion(runtime.Version())}
+	}
+
+	return []attribute.KeyValue{
+		semconv.ProcessRuntimeVersion(info.GoVersion),
+		mainModuleVersionKey.String(info.Main.Version),
+	}
+}
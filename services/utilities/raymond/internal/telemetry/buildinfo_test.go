@@ -0,0 +1,28 @@
+package telemetry
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+func TestBuildInfoAttributes(t *testing.T) {
+	res, err := resource.New(context.Background(), resource.WithAttributes(BuildInfoAttributes()...))
+	if err != nil {
+		t.Fatalf("resource.New: %v", err)
+	}
+
+	version, ok := res.Set().Value(semconv.ProcessRuntimeVersionKey)
+	if !ok {
+		t.Fatalf("resource has no %s attribute", semconv.ProcessRuntimeVersionKey)
+	}
+	if version.AsString() != runtime.Version() {
+		t.Errorf("%s = %q, want %q", semconv.ProcessRuntimeVersionKey, version.AsString(), runtime.Version())
+	}
+	if _, ok := res.Set().Value(mainModuleVersionKey); !ok {
+		t.Errorf("resource has no %s attribute", mainModuleVersionKey)
+	}
+}
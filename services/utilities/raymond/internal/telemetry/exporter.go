@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// countingSpanExporter wraps a SpanExporter and counts failed exports. The
+// batch span processor drops spans silently, so failed batches are the only
+// visible sign that traces are being lost.
+type countingSpanExporter struct {
+	sdktrace.SpanExporter
+	exportErrors metric.Int64Counter
+}
+
+// newCountingSpanExporter wraps exporter so that each failed batch increments
+// raymond.traces.export_errors_total on meter.
+func newCountingSpanExporter(exporter sdktrace.SpanExporter, meter metric.Meter) (sdktrace.SpanExporter, error) {
+	exportErrors, err := meter.Int64Counter(
+		"raymond.traces.export_errors_total",
+		metric.WithDescription("Span batches that failed to export"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create traces_export_errors metric: %w", err)
+	}
+
+	return &countingSpanExporter{
+		SpanExporter: exporter,
+		exportErrors: exportErrors,
+	}, nil
+}
+
+// ExportSpans exports spans through the wrapped exporter, counting failures.
+func (e *countingSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	if err != nil {
+		e.exportErrors.Add(ctx, 1)
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// failingSpanExporter fails every export after the first ok ones.
+type failingSpanExporter struct {
+	ok int
+}
+
+func (e *failingSpanExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	if e.ok > 0 {
+		e.ok--
+		return nil
+	}
+	return errors.New("collector unavailable")
+}
+
+func (e *failingSpanExporter) Shutdown(context.Context) error { return nil }
+
+func TestCountingSpanExporterCountsFailedBatches(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+
+	exporter, err := newCountingSpanExporter(&failingSpanExporter{ok: 1}, meter)
+	if err != nil {
+		t.Fatalf("newCountingSpanExporter: %v", err)
+	}
+
+	spans := tracetest.SpanStubs{{Name: "bootstrap.run"}}.Snapshots()
+	ctx := context.Background()
+	for range 4 {
+		exporter.ExportSpans(ctx, spans)
+	}
+
+	m, ok := collect(t, reader)["raymond.traces.export_errors_total"]
+	if !ok {
+		t.Fatal("raymond.traces.export_errors_total not recorded")
+	}
+	points := m.Data.(metricdata.Sum[int64]).DataPoints
+	if len(points) != 1 || points[0].Value != 3 {
+		t.Errorf("export_errors_total data points = %+v, want a single point of 3", points)
+	}
+}
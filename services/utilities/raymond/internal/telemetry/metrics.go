@@ -3,23 +3,67 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
 
-// Metrics holds all application metrics.
+// Names of latency histograms that have their aggregation configured by view.
+const (
+	httpRequestDurationMetric = "raymond.http.request_duration_seconds"
+	probeDurationMetric       = "raymond.dependency.probe_duration_seconds"
+)
+
+// Metrics holds all application metrics. The Record methods are no-ops on a
+// nil *Metrics.
 type Metrics struct {
 	BootstrapDuration      metric.Float64Histogram
 	BootstrapPhaseDuration metric.Float64Histogram
 	BootstrapErrors        metric.Int64Counter
+	BootstrapLastSuccess   metric.Int64Gauge
 	DependencyHealthy      metric.Int64Gauge
+	NATSConsumerPending    metric.Int64Gauge
+	ProbeDuration          metric.Float64Histogram
+	OutageDuration         metric.Float64Histogram
 	HTTPRequestsTotal      metric.Int64Counter
 	HTTPRequestDuration    metric.Float64Histogram
+	BreakerState           metric.Int64Gauge
+	BreakerTransitions     metric.Int64Counter
+
+	// meter registers per-connection observable instruments later on
+	meter metric.Meter
 }
 
-// NewMetrics creates and registers all application metrics.
+// metricsCache holds the Metrics already registered per meter so that
+// instruments are only created once, even when several components (or the
+// legacy runner and the Provider) ask for them.
+var metricsCache = struct {
+	sync.Mutex
+	byMeter map[metric.Meter]*Metrics
+}{byMeter: make(map[metric.Meter]*Metrics)}
+
+// NewMetrics creates and registers all application metrics. Calling it again
+// with the same meter returns the previously registered instruments.
 func NewMetrics(meter metric.Meter) (*Metrics, error) {
+	metricsCache.Lock()
+	defer metricsCache.Unlock()
+
+	if m, ok := metricsCache.byMeter[meter]; ok {
+		return m, nil
+	}
+
+	m, err := newMetrics(meter)
+	if err != nil {
+		return nil, err
+	}
+	metricsCache.byMeter[meter] = m
+	return m, nil
+}
+
+// newMetrics creates all application instruments on meter.
+func newMetrics(meter metric.Meter) (*Metrics, error) {
 	bootstrapDuration, err := meter.Float64Histogram(
 		"raymond.bootstrap.duration_seconds",
 		metric.WithDescription("Total bootstrap time in seconds"),
@@ -46,6 +90,15 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		return nil, fmt.Errorf("create bootstrap_errors metric: %w", err)
 	}
 
+	bootstrapLastSuccess, err := meter.Int64Gauge(
+		"raymond.bootstrap.last_success_timestamp",
+		metric.WithDescription("Unix time of the last bootstrap where all critical phases succeeded"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create bootstrap_last_success metric: %w", err)
+	}
+
 	dependencyHealthy, err := meter.Int64Gauge(
 		"raymond.dependency.healthy",
 		metric.WithDescription("Dependency health status (1=healthy, 0=unhealthy)"),
@@ -54,6 +107,32 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		return nil, fmt.Errorf("create dependency_healthy metric: %w", err)
 	}
 
+	natsConsumerPending, err := meter.Int64Gauge(
+		"raymond.nats.consumer_pending",
+		metric.WithDescription("Messages pending delivery to a JetStream consumer"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create nats_consumer_pending metric: %w", err)
+	}
+
+	probeDuration, err := meter.Float64Histogram(
+		probeDurationMetric,
+		metric.WithDescription("Dependency probe latency in seconds"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create probe_duration metric: %w", err)
+	}
+
+	outageDuration, err := meter.Float64Histogram(
+		"raymond.dependency.outage_duration_seconds",
+		metric.WithDescription("How long a dependency was unhealthy before recovering"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create outage_duration metric: %w", err)
+	}
+
 	httpRequestsTotal, err := meter.Int64Counter(
 		"raymond.http.requests_total",
 		metric.WithDescription("HTTP requests by endpoint and status"),
@@ -63,7 +142,7 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 	}
 
 	httpRequestDuration, err := meter.Float64Histogram(
-		"raymond.http.request_duration_seconds",
+		httpRequestDurationMetric,
 		metric.WithDescription("HTTP request latency in seconds"),
 		metric.WithUnit("s"),
 	)
@@ -71,35 +150,108 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		return nil, fmt.Errorf("create http_request_duration metric: %w", err)
 	}
 
+	breakerState, err := meter.Int64Gauge(
+		"raymond.circuit_breaker.state",
+		metric.WithDescription("Client circuit breaker state (0=closed, 1=half-open, 2=open)"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create circuit_breaker_state metric: %w", err)
+	}
+
+	breakerTransitions, err := meter.Int64Counter(
+		"raymond.circuit_breaker.transitions_total",
+		metric.WithDescription("Client circuit breaker state changes"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create circuit_breaker_transitions metric: %w", err)
+	}
+
 	return &Metrics{
 		BootstrapDuration:      bootstrapDuration,
 		BootstrapPhaseDuration: bootstrapPhaseDuration,
 		BootstrapErrors:        bootstrapErrors,
+		BootstrapLastSuccess:   bootstrapLastSuccess,
 		DependencyHealthy:      dependencyHealthy,
+		NATSConsumerPending:    natsConsumerPending,
+		ProbeDuration:          probeDuration,
+		OutageDuration:         outageDuration,
 		HTTPRequestsTotal:      httpRequestsTotal,
 		HTTPRequestDuration:    httpRequestDuration,
+		BreakerState:           breakerState,
+		BreakerTransitions:     breakerTransitions,
+		meter:                  meter,
 	}, nil
 }
 
 // RecordBootstrapDuration records the total bootstrap time.
 func (m *Metrics) RecordBootstrapDuration(ctx context.Context, seconds float64) {
+	if m == nil {
+		return
+	}
 	m.BootstrapDuration.Record(ctx, seconds)
 }
 
 // RecordBootstrapPhase records a phase duration with phase label.
 func (m *Metrics) RecordBootstrapPhase(ctx context.Context, phase string, seconds float64) {
+	if m == nil {
+		return
+	}
 	attrs := attribute.NewSet(attribute.String("phase", phase))
 	m.BootstrapPhaseDuration.Record(ctx, seconds, metric.WithAttributeSet(attrs))
 }
 
 // RecordBootstrapError increments error counter for a phase.
 func (m *Metrics) RecordBootstrapError(ctx context.Context, phase string) {
+	if m == nil {
+		return
+	}
 	attrs := attribute.NewSet(attribute.String("phase", phase))
 	m.BootstrapErrors.Add(ctx, 1, metric.WithAttributeSet(attrs))
 }
 
+// RecordBootstrapSuccess records when all critical bootstrap phases completed.
+func (m *Metrics) RecordBootstrapSuccess(ctx context.Context, at time.Time) {
+	if m == nil {
+		return
+	}
+	m.BootstrapLastSuccess.Record(ctx, at.Unix())
+}
+
+// RecordProbe records a dependency probe's latency labeled by dependency name.
+func (m *Metrics) RecordProbe(ctx context.Context, name string, seconds float64) {
+	if m == nil {
+		return
+	}
+	attrs := attribute.NewSet(attribute.String("service", name))
+	m.ProbeDuration.Record(ctx, seconds, metric.WithAttributeSet(attrs))
+}
+
+// RecordOutage records how long a dependency was unhealthy once it recovers.
+func (m *Metrics) RecordOutage(ctx context.Context, name string, seconds float64) {
+	if m == nil {
+		return
+	}
+	attrs := attribute.NewSet(attribute.String("service", name))
+	m.OutageDuration.Record(ctx, seconds, metric.WithAttributeSet(attrs))
+}
+
+// RecordConsumerPending records a JetStream consumer's pending message count.
+func (m *Metrics) RecordConsumerPending(ctx context.Context, stream, consumer string, pending uint64) {
+	if m == nil {
+		return
+	}
+	attrs := attribute.NewSet(
+		attribute.String("stream", stream),
+		attribute.String("consumer", consumer),
+	)
+	m.NATSConsumerPending.Record(ctx, int64(pending), metric.WithAttributeSet(attrs))
+}
+
 // RecordHTTPRequest records HTTP request metrics.
 func (m *Metrics) RecordHTTPRequest(ctx context.Context, method, path string, status int, duration float64) {
+	if m == nil {
+		return
+	}
 	attrs := attribute.NewSet(
 		attribute.String("method", method),
 		attribute.String("path", path),
@@ -113,3 +265,19 @@ func (m *Metrics) RecordHTTPRequest(ctx context.Context, method, path string, st
 	)
 	m.HTTPRequestDuration.Record(ctx, duration, metric.WithAttributeSet(durationAttrs))
 }
+
+// RecordBreakerStateChange records a circuit breaker moving from one state to
+// another. state is the numeric new state (0=closed, 1=half-open, 2=open).
+func (m *Metrics) RecordBreakerStateChange(ctx context.Context, name, from, to string, state int64) {
+	if m == nil {
+		return
+	}
+	m.BreakerState.Record(ctx, state, metric.WithAttributeSet(attribute.NewSet(
+		attribute.String("breaker", name),
+	)))
+	m.BreakerTransitions.Add(ctx, 1, metric.WithAttributeSet(attribute.NewSet(
+		attribute.String("breaker", name),
+		attribute.String("from", from),
+		attribute.String("to", to),
+	)))
+}
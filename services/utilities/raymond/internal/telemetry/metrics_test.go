@@ -0,0 +1,39 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewMetricsTwiceRegistersOnce(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+
+	first, err := NewMetrics(meter)
+	if err != nil {
+		t.Fatalf("first NewMetrics: %v", err)
+	}
+	second, err := NewMetrics(meter)
+	if err != nil {
+		t.Fatalf("second NewMetrics: %v", err)
+	}
+	if first != second {
+		t.Error("second NewMetrics created new instruments for the same meter")
+	}
+
+	ctx := context.Background()
+	first.RecordBootstrapError(ctx, "initialize_nats")
+	second.RecordBootstrapError(ctx, "initialize_nats")
+
+	m, ok := collect(t, reader)["raymond.bootstrap.errors_total"]
+	if !ok {
+		t.Fatal("raymond.bootstrap.errors_total not recorded")
+	}
+	points := m.Data.(metricdata.Sum[int64]).DataPoints
+	if len(points) != 1 || points[0].Value != 2 {
+		t.Errorf("errors_total data points = %+v, want a single point of 2", points)
+	}
+}
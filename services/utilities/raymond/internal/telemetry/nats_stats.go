@@ -0,0 +1,72 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// NATSStatsSource reports the statistics of a NATS connection.
+type NATSStatsSource interface {
+	ConnName() string
+	Stats() nats.Statistics
+}
+
+// ObserveNATSConn registers observable counters reporting src's reconnects
+// and in/out message and byte totals, labeled by connection name. The returned
+// function unregisters them and should be called when the connection closes.
+func (m *Metrics) ObserveNATSConn(src NATSStatsSource) (func() error, error) {
+	if m == nil {
+		return func() error { return nil }, nil
+	}
+
+	counter := func(name, description, unit string) (metric.Int64ObservableCounter, error) {
+		c, err := m.meter.Int64ObservableCounter(name,
+			metric.WithDescription(description),
+			metric.WithUnit(unit),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("create %s metric: %w", name, err)
+		}
+		return c, nil
+	}
+
+	reconnects, err := counter("raymond.nats.reconnects_total", "NATS connection reconnects", "")
+	if err != nil {
+		return nil, err
+	}
+	inMsgs, err := counter("raymond.nats.in_msgs_total", "Messages received on the NATS connection", "")
+	if err != nil {
+		return nil, err
+	}
+	outMsgs, err := counter("raymond.nats.out_msgs_total", "Messages sent on the NATS connection", "")
+	if err != nil {
+		return nil, err
+	}
+	inBytes, err := counter("raymond.nats.in_bytes_total", "Bytes received on the NATS connection", "By")
+	if err != nil {
+		return nil, err
+	}
+	outBytes, err := counter("raymond.nats.out_bytes_total", "Bytes sent on the NATS connection", "By")
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := metric.WithAttributeSet(attribute.NewSet(attribute.String("connection", src.ConnName())))
+	reg, err := m.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := src.Stats()
+		o.ObserveInt64(reconnects, int64(stats.Reconnects), attrs)
+		o.ObserveInt64(inMsgs, int64(stats.InMsgs), attrs)
+		o.ObserveInt64(outMsgs, int64(stats.OutMsgs), attrs)
+		o.ObserveInt64(inBytes, int64(stats.InBytes), attrs)
+		o.ObserveInt64(outBytes, int64(stats.OutBytes), attrs)
+		return nil
+	}, reconnects, inMsgs, outMsgs, inBytes, outBytes)
+	if err != nil {
+		return nil, fmt.Errorf("register nats stats callback: %w", err)
+	}
+	return reg.Unregister, nil
+}
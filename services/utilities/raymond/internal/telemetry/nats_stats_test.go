@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// fakeNATSStats is a NATSStatsSource with settable statistics.
+type fakeNATSStats struct {
+	mu    sync.Mutex
+	stats nats.Statistics
+}
+
+func (f *fakeNATSStats) ConnName() string { return "raymond-bootstrap" }
+
+func (f *fakeNATSStats) Stats() nats.Statistics {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.stats
+}
+
+func (f *fakeNATSStats) set(stats nats.Statistics) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stats = stats
+}
+
+// natsStats collects the NATS connection counters as name to value, checking
+// each is labeled with the connection name.
+func natsStats(t *testing.T, byName map[string]metricdata.Metrics) map[string]int64 {
+	t.Helper()
+	values := make(map[string]int64)
+	for _, name := range []string{
+		"raymond.nats.reconnects_total",
+		"raymond.nats.in_msgs_total",
+		"raymond.nats.out_msgs_total",
+		"raymond.nats.in_bytes_total",
+		"raymond.nats.out_bytes_total",
+	} {
+		m, ok := byName[name]
+		if !ok {
+			continue
+		}
+		for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+			if conn, _ := point.Attributes.Value(attribute.Key("connection")); conn.AsString() != "raymond-bootstrap" {
+				t.Errorf("%s connection = %q, want %q", name, conn.AsString(), "raymond-bootstrap")
+			}
+			values[name] = point.Value
+		}
+	}
+	return values
+}
+
+func TestObserveNATSConnReportsStats(t *testing.T) {
+	metrics, reader, err := NewTestMetrics()
+	if err != nil {
+		t.Fatalf("NewTestMetrics: %v", err)
+	}
+	src := &fakeNATSStats{}
+	unregister, err := metrics.ObserveNATSConn(src)
+	if err != nil {
+		t.Fatalf("ObserveNATSConn: %v", err)
+	}
+
+	src.set(nats.Statistics{InMsgs: 12, OutMsgs: 7, InBytes: 2048, OutBytes: 512, Reconnects: 2})
+	want := map[string]int64{
+		"raymond.nats.reconnects_total": 2,
+		"raymond.nats.in_msgs_total":    12,
+		"raymond.nats.out_msgs_total":   7,
+		"raymond.nats.in_bytes_total":   2048,
+		"raymond.nats.out_bytes_total":  512,
+	}
+	got := natsStats(t, collect(t, reader))
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("%s = %d, want %d", name, got[name], value)
+		}
+	}
+
+	// Each collection reads the connection's current stats
+	src.set(nats.Statistics{Reconnects: 3})
+	if got := natsStats(t, collect(t, reader))["raymond.nats.reconnects_total"]; got != 3 {
+		t.Errorf("reconnects_total after another reconnect = %d, want 3", got)
+	}
+
+	if err := unregister(); err != nil {
+		t.Fatalf("unregister: %v", err)
+	}
+	if got := natsStats(t, collect(t, reader)); len(got) != 0 {
+		t.Errorf("stats after unregister = %v, want none", got)
+	}
+}
@@ -0,0 +1,22 @@
+package telemetry
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// NewNoopProvider returns a Provider whose tracer and meter discard all data
+// and whose logger writes JSON to stdout. It opens no connections, making it
+// suitable for injecting into unit tests. Shutdown is a no-op.
+func NewNoopProvider() *Provider {
+	return &Provider{
+		logger:       slog.New(slog.NewJSONHandler(os.Stdout, nil)),
+		tracer:       tracenoop.NewTracerProvider().Tracer("noop"),
+		meter:        metricnoop.NewMeterProvider().Meter("noop"),
+		shutdownFunc: func(context.Context) error { return nil },
+	}
+}
@@ -0,0 +1,35 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNoopProvider(t *testing.T) {
+	p := NewNoopProvider()
+	ctx := context.Background()
+
+	if p.Logger() == nil {
+		t.Fatal("Logger() = nil")
+	}
+
+	_, span := p.Tracer().Start(ctx, "bootstrap.run")
+	if span.SpanContext().IsValid() {
+		t.Error("noop tracer produced a recording span")
+	}
+	span.End()
+
+	metrics, err := NewMetrics(p.Meter())
+	if err != nil {
+		t.Fatalf("NewMetrics on the noop meter: %v", err)
+	}
+	metrics.RecordBootstrapDuration(ctx, 1.5)
+	metrics.RecordProbe(ctx, "postgres", 0.01)
+
+	if err := p.Shutdown(ctx); err != nil {
+		t.Errorf("Shutdown = %v, want nil", err)
+	}
+	if err := p.Shutdown(ctx); err != nil {
+		t.Errorf("second Shutdown = %v, want nil", err)
+	}
+}
@@ -2,35 +2,65 @@ package telemetry
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Provider manages OpenTelemetry SDK resources.
 type Provider struct {
 	logger       *slog.Logger
+	level        *slog.LevelVar
+	sampler      *LogSampler
 	tracer       trace.Tracer
 	meter        metric.Meter
+	conns        *connPool
+	metrics      http.Handler
 	shutdownFunc func(context.Context) error
 }
 
-// NewProvider initializes the OpenTelemetry SDK with OTLP exporters.
-func NewProvider(ctx context.Context, endpoint string, useInsecure bool, serviceName string, logLevel string) (*Provider, error) {
+// NewProvider initializes the OpenTelemetry SDK with OTLP exporters. Each
+// signal goes to its own *_endpoint when set and to otlp_endpoint otherwise.
+// Traces go to the Jaeger collector instead when trace_exporter is "jaeger", and are
+// attributed to trace_service_name when it is set. With enable_logs, log
+// records are exported over OTLP as well as written to stdout. Disabled
+// signals get no exporter and a no-op tracer or meter. With enable_prometheus,
+// metrics can also be scraped through MetricsHandler. Unless otlp_insecure is
+// set, the collector connection uses TLS based on tlsCfg, verified against
+// otlp_ca_file or the system pool, with an optional client certificate.
+func NewProvider(ctx context.Context, cfg *config.TelemetryConfig, tlsCfg *tls.Config) (*Provider, error) {
+	serviceName := cfg.ServiceName
+
 	// Create resource with service metadata
 	res, err := resource.New(ctx,
 		resource.WithAttributes(
@@ -38,6 +68,7 @@ func NewProvider(ctx context.Context, endpoint string, useInsecure bool, service
 			semconv.ServiceVersion("1.0.0"),
 			semconv.ServiceNamespace("arc"),
 		),
+		resource.WithAttributes(BuildInfoAttributes()...),
 		resource.WithHost(),
 		resource.WithOS(),
 		resource.WithProcess(),
@@ -46,49 +77,117 @@ func NewProvider(ctx context.Context, endpoint string, useInsecure bool, service
 		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
 
-	// Create shared gRPC connection for all exporters
+	// Signals without an endpoint of their own share the otlp_endpoint connection
 	var dialOpts []grpc.DialOption
-	if useInsecure {
+	if cfg.OTLPInsecure {
 		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	} else {
-		// In production, use TLS credentials
-		// TODO: Add proper TLS configuration
-		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		collectorTLS, err := collectorTLSConfig(cfg, tlsCfg)
+		if err != nil {
+			return nil, err
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(collectorTLS)))
 	}
 
-	conn, err := grpc.NewClient(endpoint, dialOpts...)
+	conns := newConnPool(dialOpts)
+	conn, err := conns.get(cfg.OTLPEndpoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create gRPC connection: %w", err)
+		return nil, err
 	}
 
-	// Initialize trace exporter and provider
-	traceExporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithGRPCConn(conn))
-	if err != nil {
-		conn.Close()
-		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	cleanup := func(meterProvider *sdkmetric.MeterProvider) {
+		if meterProvider != nil {
+			meterProvider.Shutdown(ctx)
+		}
+		conns.Close()
 	}
 
-	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithResource(res),
-		sdktrace.WithBatcher(traceExporter),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-	)
-	otel.SetTracerProvider(tracerProvider)
-
 	// Initialize metric exporter and provider
-	metricExporter, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithGRPCConn(conn))
-	if err != nil {
-		tracerProvider.Shutdown(ctx)
-		conn.Close()
-		return nil, fmt.Errorf("failed to create metric exporter: %w", err)
+	var meterProvider *sdkmetric.MeterProvider
+	var metricsHandler http.Handler
+	meter := metricnoop.NewMeterProvider().Meter(serviceName)
+	var readers []sdkmetric.Option
+	if cfg.EnableMetrics {
+		metricConn, err := conns.get(signalEndpoint(cfg.MetricEndpoint, cfg.OTLPEndpoint))
+		if err != nil {
+			cleanup(nil)
+			return nil, err
+		}
+
+		metricExporter, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithGRPCConn(metricConn))
+		if err != nil {
+			cleanup(nil)
+			return nil, fmt.Errorf("failed to create metric exporter: %w", err)
+		}
+		readers = append(readers, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter,
+			sdkmetric.WithInterval(10*time.Second))))
+	}
+	if cfg.EnablePrometheus {
+		// A registry of our own keeps the scrape to this provider's metrics
+		registry := prom.NewRegistry()
+		promExporter, err := otelprom.New(otelprom.WithRegisterer(registry))
+		if err != nil {
+			cleanup(nil)
+			return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+		}
+		readers = append(readers, sdkmetric.WithReader(promExporter))
+		metricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+	if len(readers) > 0 {
+		meterProvider = sdkmetric.NewMeterProvider(append(readers,
+			sdkmetric.WithResource(res),
+			sdkmetric.WithView(histogramViews(cfg.HistogramType)...),
+			// Measurements taken inside a sampled span keep its trace ID,
+			// linking e.g. a slow probe on the latency histogram to its trace
+			sdkmetric.WithExemplarFilter(exemplar.TraceBasedFilter),
+		)...)
+		otel.SetMeterProvider(meterProvider)
+		meter = meterProvider.Meter(serviceName)
 	}
 
-	meterProvider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter,
-			sdkmetric.WithInterval(10*time.Second))),
-	)
-	otel.SetMeterProvider(meterProvider)
+	// Initialize trace exporter and provider
+	var tracerProvider *sdktrace.TracerProvider
+	tracer := tracenoop.NewTracerProvider().Tracer(serviceName)
+	if cfg.EnableTraces {
+		traceEndpoint := signalEndpoint(cfg.TraceEndpoint, cfg.OTLPEndpoint)
+		if cfg.TraceExporter == "jaeger" {
+			// Jaeger's collector accepts OTLP natively, so only the endpoint differs
+			traceEndpoint = cfg.JaegerEndpoint
+		}
+
+		traceConn, err := conns.get(traceEndpoint)
+		if err != nil {
+			cleanup(meterProvider)
+			return nil, err
+		}
+
+		traceExporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithGRPCConn(traceConn))
+		if err != nil {
+			cleanup(meterProvider)
+			return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+		}
+
+		countingExporter, err := newCountingSpanExporter(traceExporter, meter)
+		if err != nil {
+			cleanup(meterProvider)
+			return nil, err
+		}
+
+		traceRes, err := WithServiceName(res, cfg.TraceServiceName)
+		if err != nil {
+			cleanup(meterProvider)
+			return nil, err
+		}
+
+		tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithResource(traceRes),
+			sdktrace.WithBatcher(countingExporter),
+			sdktrace.WithSampler(Sampler(cfg.TraceSampleRatio)),
+			sdktrace.WithRawSpanLimits(spanLimits(cfg)),
+		)
+		otel.SetTracerProvider(tracerProvider)
+		tracer = tracerProvider.Tracer(serviceName)
+	}
 
 	// Set global propagator for context propagation
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
@@ -96,41 +195,84 @@ func NewProvider(ctx context.Context, endpoint string, useInsecure bool, service
 		propagation.Baggage{},
 	))
 
-	// Create structured logger with JSON output
-	// The OTEL collector will capture these logs from stdout
-	level := parseLogLevel(logLevel)
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: level,
-		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			// Add service metadata to all log entries
-			if a.Key == slog.SourceKey {
-				return slog.Attr{}
-			}
-			return a
-		},
-	}))
-
-	// Add service context to logger
-	logger = logger.With(
-		"service.name", serviceName,
-		"service.version", "1.0.0",
-		"service.namespace", "arc",
-	)
+	// Structured JSON logs always go to stdout
+	level := new(slog.LevelVar)
+	level.Set(parseLogLevel(cfg.LogLevel))
+	var handler slog.Handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: logFieldReplacer(cfg.LogFieldMap),
+	})
+
+	// Add service context to stdout entries; OTLP records carry it in the resource
+	handler = handler.WithAttrs([]slog.Attr{
+		slog.String("service.name", serviceName),
+		slog.String("service.version", "1.0.0"),
+		slog.String("service.namespace", "arc"),
+	})
+
+	// Initialize log exporter and provider
+	var loggerProvider *sdklog.LoggerProvider
+	if cfg.EnableLogs {
+		logConn, err := conns.get(signalEndpoint(cfg.LogEndpoint, cfg.OTLPEndpoint))
+		if err != nil {
+			cleanup(meterProvider)
+			return nil, err
+		}
+
+		logExporter, err := otlploggrpc.New(ctx, otlploggrpc.WithGRPCConn(logConn))
+		if err != nil {
+			cleanup(meterProvider)
+			return nil, fmt.Errorf("failed to create log exporter: %w", err)
+		}
+
+		logRes, err := WithServiceName(res, cfg.LogServiceName)
+		if err != nil {
+			cleanup(meterProvider)
+			return nil, err
+		}
+
+		loggerProvider = sdklog.NewLoggerProvider(
+			sdklog.WithResource(logRes),
+			sdklog.WithProcessor(sdklog.NewBatchProcessor(logExporter)),
+		)
+		global.SetLoggerProvider(loggerProvider)
+
+		handler = NewMultiSlogHandler(handler, NewSlogOtelHandler(loggerProvider.Logger(serviceName), level, cfg.LogAttributeValueLengthLimit))
+	}
+	sampler := NewLogSampler(cfg.LogSampleRate)
+	logger := slog.New(sampler.Handler(handler))
 
-	// Create tracer and meter instances
-	tracer := tracerProvider.Tracer(serviceName)
-	meter := meterProvider.Meter(serviceName)
+	if cfg.StartupSelftest {
+		selfTest(ctx, conn, tracerProvider, meterProvider, logger)
+	}
 
 	// Define shutdown function for graceful cleanup
 	shutdownFunc := func(ctx context.Context) error {
 		var errs []error
-		if err := meterProvider.Shutdown(ctx); err != nil {
-			errs = append(errs, fmt.Errorf("meter provider shutdown: %w", err))
+		if meterProvider != nil {
+			// Export metrics recorded since the last periodic collection
+			if err := meterProvider.ForceFlush(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("meter provider flush: %w", err))
+			}
 		}
-		if err := tracerProvider.Shutdown(ctx); err != nil {
-			errs = append(errs, fmt.Errorf("tracer provider shutdown: %w", err))
+		if loggerProvider != nil {
+			// Shutdown flushes the batch processor before closing the exporter
+			if err := loggerProvider.Shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("logger provider shutdown: %w", err))
+			}
+		}
+		// Traces first, so export errors from the final flush are still counted
+		if tracerProvider != nil {
+			if err := tracerProvider.Shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("tracer provider shutdown: %w", err))
+			}
 		}
-		if err := conn.Close(); err != nil {
+		if meterProvider != nil {
+			if err := meterProvider.Shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("meter provider shutdown: %w", err))
+			}
+		}
+		if err := conns.Close(); err != nil {
 			errs = append(errs, fmt.Errorf("grpc connection close: %w", err))
 		}
 		if len(errs) > 0 {
@@ -141,17 +283,48 @@ func NewProvider(ctx context.Context, endpoint string, useInsecure bool, service
 
 	return &Provider{
 		logger:       logger,
+		level:        level,
+		sampler:      sampler,
 		tracer:       tracer,
 		meter:        meter,
+		conns:        conns,
+		metrics:      metricsHandler,
 		shutdownFunc: shutdownFunc,
 	}, nil
 }
 
+// logFieldReplacer returns a slog ReplaceAttr function that drops the source
+// and renames the standard keys (msg, level, time) to what the log pipeline
+// expects, as given by fieldMap.
+func logFieldReplacer(fieldMap map[string]string) func([]string, slog.Attr) slog.Attr {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.SourceKey {
+			return slog.Attr{}
+		}
+		if renamed, ok := fieldMap[a.Key]; ok && len(groups) == 0 {
+			a.Key = renamed
+		}
+		return a
+	}
+}
+
 // Logger returns the structured logger.
 func (p *Provider) Logger() *slog.Logger {
 	return p.logger
 }
 
+// SetLogLevel changes the log level of stdout and OTLP logs at runtime, e.g.
+// after a config reload. Unknown levels fall back to info.
+func (p *Provider) SetLogLevel(level string) {
+	p.level.Set(parseLogLevel(level))
+}
+
+// LogSampler returns the sampler applied to debug and info records, whose
+// rate can be changed at runtime.
+func (p *Provider) LogSampler() *LogSampler {
+	return p.sampler
+}
+
 // Tracer returns the OpenTelemetry tracer.
 func (p *Provider) Tracer() trace.Tracer {
 	return p.tracer
@@ -162,11 +335,103 @@ func (p *Provider) Meter() metric.Meter {
 	return p.meter
 }
 
+// MetricsHandler returns the handler serving metrics for Prometheus to
+// scrape, or nil unless enable_prometheus is set.
+func (p *Provider) MetricsHandler() http.Handler {
+	return p.metrics
+}
+
+// Readiness reports whether every collector connection is usable, for
+// environments where the service must not serve when telemetry can't be
+// exported (health.ready_requires_telemetry). Connections that are idle or
+// still connecting count as usable; only a failed connection does not. It
+// only reads connection state: gRPC reconnects failed connections on its own
+// backoff, so readiness probes don't cause extra connection attempts.
+func (p *Provider) Readiness() (bool, string) {
+	for endpoint, conn := range p.conns.conns {
+		switch state := conn.GetState(); state {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return false, fmt.Sprintf("telemetry collector %s is %s", endpoint, strings.ToLower(state.String()))
+		}
+	}
+	return true, "telemetry pipeline connected"
+}
+
 // Shutdown gracefully shuts down all telemetry providers.
 func (p *Provider) Shutdown(ctx context.Context) error {
 	return p.shutdownFunc(ctx)
 }
 
+// signalEndpoint returns the signal's own endpoint, or the shared one when
+// it is unset.
+func signalEndpoint(endpoint, shared string) string {
+	if endpoint != "" {
+		return endpoint
+	}
+	return shared
+}
+
+// connPool hands out one gRPC connection per collector endpoint, so signals
+// sent to the same collector share a connection.
+type connPool struct {
+	dialOpts []grpc.DialOption
+	conns    map[string]*grpc.ClientConn
+}
+
+func newConnPool(dialOpts []grpc.DialOption) *connPool {
+	return &connPool{dialOpts: dialOpts, conns: make(map[string]*grpc.ClientConn)}
+}
+
+// get returns the connection to endpoint, creating it on first use.
+func (p *connPool) get(endpoint string) (*grpc.ClientConn, error) {
+	if conn, ok := p.conns[endpoint]; ok {
+		return conn, nil
+	}
+
+	conn, err := grpc.NewClient(endpoint, p.dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC connection to %s: %w", endpoint, err)
+	}
+	p.conns[endpoint] = conn
+	return conn, nil
+}
+
+// Close closes every connection in the pool.
+func (p *connPool) Close() error {
+	var errs []error
+	for _, conn := range p.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// histogramViews returns the views that select the aggregation for latency
+// histograms. Explicit buckets need no view since they are the SDK default.
+func histogramViews(histogramType string) []sdkmetric.View {
+	if histogramType != "exponential" {
+		return nil
+	}
+
+	aggregation := sdkmetric.AggregationBase2ExponentialHistogram{
+		MaxSize:  160,
+		MaxScale: 20,
+	}
+
+	latencyHistograms := []string{
+		httpRequestDurationMetric,
+		probeDurationMetric,
+	}
+
+	views := make([]sdkmetric.View, 0, len(latencyHistograms))
+	for _, name := range latencyHistograms {
+		views = append(views, sdkmetric.NewView(
+			sdkmetric.Instrument{Name: name},
+			sdkmetric.Stream{Aggregation: aggregation},
+		))
+	}
+	return views
+}
+
 // parseLogLevel converts string log level to slog.Level.
 func parseLogLevel(level string) slog.Level {
 	switch level {
@@ -182,3 +447,26 @@ func parseLogLevel(level string) slog.Level {
 		return slog.LevelInfo
 	}
 }
+
+// Sampler returns the sampler for a trace sample ratio. Below 1 it samples
+// that fraction of new traces while always following the parent's decision,
+// so a ratio of 0 keeps only spans whose parent was sampled.
+func Sampler(ratio float64) sdktrace.Sampler {
+	if ratio >= 1 {
+		return sdktrace.AlwaysSample()
+	}
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+}
+
+// spanLimits applies the configured attribute limits on top of the SDK
+// defaults, which already honor the OTEL_SPAN_ATTRIBUTE_* environment.
+func spanLimits(cfg *config.TelemetryConfig) sdktrace.SpanLimits {
+	limits := sdktrace.NewSpanLimits()
+	if cfg.SpanAttributeCountLimit > 0 {
+		limits.AttributeCountLimit = cfg.SpanAttributeCountLimit
+	}
+	if cfg.SpanAttributeValueLengthLimit > 0 {
+		limits.AttributeValueLengthLimit = cfg.SpanAttributeValueLengthLimit
+	}
+	return limits
+}
@@ -0,0 +1,470 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"go.opentelemetry.io/otel/attribute"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+)
+
+// collect returns the metrics recorded through reader, by name.
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Metrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect metrics: %v", err)
+	}
+	byName := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			byName[m.Name] = m
+		}
+	}
+	return byName
+}
+
+func TestHistogramViews(t *testing.T) {
+	tests := []struct {
+		histogramType string
+		exponential   bool
+	}{
+		{"explicit", false},
+		{"exponential", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.histogramType, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			provider := sdkmetric.NewMeterProvider(
+				sdkmetric.WithReader(reader),
+				sdkmetric.WithView(histogramViews(tt.histogramType)...),
+			)
+			metrics, err := NewMetrics(provider.Meter("test"))
+			if err != nil {
+				t.Fatalf("NewMetrics: %v", err)
+			}
+
+			ctx := context.Background()
+			metrics.RecordHTTPRequest(ctx, "GET", "/health", 200, 0.012)
+			metrics.RecordProbe(ctx, "postgres", 0.003)
+
+			byName := collect(t, reader)
+			for _, name := range []string{httpRequestDurationMetric, probeDurationMetric} {
+				m, ok := byName[name]
+				if !ok {
+					t.Fatalf("%s not recorded", name)
+				}
+				_, isExponential := m.Data.(metricdata.ExponentialHistogram[float64])
+				if isExponential != tt.exponential {
+					t.Errorf("%s aggregation = %T, want exponential %v", name, m.Data, tt.exponential)
+				}
+			}
+		})
+	}
+}
+
+// closedAddress returns a local address nothing listens on.
+func closedAddress(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+	return addr
+}
+
+func TestNewProviderJaegerExporter(t *testing.T) {
+	jaeger, addr := serveTraceCollector(t)
+
+	cfg := &config.TelemetryConfig{
+		OTLPEndpoint:     closedAddress(t), // must not receive the traces
+		OTLPInsecure:     true,
+		ServiceName:      "raymond-test",
+		LogLevel:         "error",
+		HistogramType:    "explicit",
+		TraceExporter:    "jaeger",
+		JaegerEndpoint:   addr,
+		TraceSampleRatio: 1,
+		EnableTraces:     true,
+	}
+
+	ctx := context.Background()
+	provider, err := NewProvider(ctx, cfg, nil)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	_, span := provider.Tracer().Start(ctx, "bootstrap.run")
+	span.End()
+
+	// Shutdown flushes the batched span to the exporter
+	if err := provider.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if jaeger.spans.Load() != 1 {
+		t.Errorf("Jaeger received %d spans, want 1", jaeger.spans.Load())
+	}
+}
+
+// fakeMetricsCollector accepts OTLP metric exports and keeps the names of the
+// metrics received.
+type fakeMetricsCollector struct {
+	colmetricpb.UnimplementedMetricsServiceServer
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+func (c *fakeMetricsCollector) Export(_ context.Context, req *colmetricpb.ExportMetricsServiceRequest) (*colmetricpb.ExportMetricsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rm := range req.ResourceMetrics {
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				c.names[m.Name] = true
+			}
+		}
+	}
+	return &colmetricpb.ExportMetricsServiceResponse{}, nil
+}
+
+func (c *fakeMetricsCollector) received(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.names[name]
+}
+
+// serveMetricsCollector starts a fake OTLP metrics collector and returns it
+// with its address.
+func serveMetricsCollector(t *testing.T) (*fakeMetricsCollector, string) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	collector := &fakeMetricsCollector{names: make(map[string]bool)}
+	srv := grpc.NewServer()
+	colmetricpb.RegisterMetricsServiceServer(srv, collector)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return collector, lis.Addr().String()
+}
+
+func TestShutdownFlushesMetrics(t *testing.T) {
+	collector, addr := serveMetricsCollector(t)
+
+	cfg := &config.TelemetryConfig{
+		OTLPEndpoint:  addr,
+		OTLPInsecure:  true,
+		ServiceName:   "raymond-test",
+		LogLevel:      "error",
+		HistogramType: "explicit",
+		TraceExporter: "otlp",
+		EnableMetrics: true,
+	}
+
+	ctx := context.Background()
+	provider, err := NewProvider(ctx, cfg, nil)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	metrics, err := NewMetrics(provider.Meter())
+	if err != nil {
+		t.Fatalf("NewMetrics: %v", err)
+	}
+
+	// Recorded well within the 10s export interval, so only the final flush
+	// can deliver it
+	metrics.RecordBootstrapDuration(ctx, 4.2)
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := provider.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if !collector.received("raymond.bootstrap.duration_seconds") {
+		t.Error("bootstrap duration recorded before shutdown was not exported")
+	}
+}
+
+func TestNewProviderDisabledSignals(t *testing.T) {
+	tests := []struct {
+		name          string
+		enableTraces  bool
+		enableMetrics bool
+	}{
+		{"metrics disabled", true, false},
+		{"traces disabled", false, true},
+		{"all disabled", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.TelemetryConfig{
+				OTLPEndpoint:   closedAddress(t),
+				TraceEndpoint:  closedAddress(t),
+				MetricEndpoint: closedAddress(t),
+				OTLPInsecure:   true,
+				ServiceName:    "raymond-test",
+				LogLevel:       "error",
+				HistogramType:  "explicit",
+				TraceExporter:  "otlp",
+				EnableTraces:   tt.enableTraces,
+				EnableMetrics:  tt.enableMetrics,
+			}
+
+			provider, err := NewProvider(context.Background(), cfg, nil)
+			if err != nil {
+				t.Fatalf("NewProvider: %v", err)
+			}
+			defer func() {
+				// Nothing is listening, so don't wait on exporting at shutdown
+				ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				defer cancel()
+				provider.Shutdown(ctx)
+			}()
+
+			_, noopMeter := provider.Meter().(metricnoop.Meter)
+			if noopMeter == tt.enableMetrics {
+				t.Errorf("meter is %T, want no-op %v", provider.Meter(), !tt.enableMetrics)
+			}
+			_, noopTracer := provider.Tracer().(tracenoop.Tracer)
+			if noopTracer == tt.enableTraces {
+				t.Errorf("tracer is %T, want no-op %v", provider.Tracer(), !tt.enableTraces)
+			}
+
+			// A disabled signal doesn't even connect to its collector
+			if _, ok := provider.conns.conns[cfg.MetricEndpoint]; ok != tt.enableMetrics {
+				t.Errorf("metric collector connection = %v, want %v", ok, tt.enableMetrics)
+			}
+			if _, ok := provider.conns.conns[cfg.TraceEndpoint]; ok != tt.enableTraces {
+				t.Errorf("trace collector connection = %v, want %v", ok, tt.enableTraces)
+			}
+		})
+	}
+}
+
+func TestSpanLimitsTruncateAttributes(t *testing.T) {
+	cfg := &config.TelemetryConfig{SpanAttributeCountLimit: 4, SpanAttributeValueLengthLimit: 8}
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+		sdktrace.WithRawSpanLimits(spanLimits(cfg)),
+	)
+
+	_, span := tracerProvider.Tracer("test").Start(context.Background(), "bootstrap.run")
+	for i := range 10 {
+		span.SetAttributes(attribute.String(fmt.Sprintf("attr.%d", i), "a value longer than the limit"))
+	}
+	span.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("ended spans = %d, want 1", len(ended))
+	}
+	attrs := ended[0].Attributes()
+	if len(attrs) != 4 || ended[0].DroppedAttributes() != 6 {
+		t.Errorf("attributes = %d, dropped %d, want 4, dropped 6", len(attrs), ended[0].DroppedAttributes())
+	}
+	for _, attr := range attrs {
+		if got := attr.Value.AsString(); got != "a value " {
+			t.Errorf("%s = %q, want %q", attr.Key, got, "a value ")
+		}
+	}
+}
+
+func TestNewProviderPerSignalEndpoints(t *testing.T) {
+	traceCollector, traceAddr := serveTraceCollector(t)
+	metricCollector, metricAddr := serveMetricsCollector(t)
+
+	cfg := &config.TelemetryConfig{
+		OTLPEndpoint:     closedAddress(t), // must not receive traces or metrics
+		TraceEndpoint:    traceAddr,
+		MetricEndpoint:   metricAddr,
+		OTLPInsecure:     true,
+		ServiceName:      "raymond-test",
+		LogLevel:         "error",
+		HistogramType:    "explicit",
+		TraceExporter:    "otlp",
+		TraceSampleRatio: 1,
+		EnableTraces:     true,
+		EnableMetrics:    true,
+	}
+
+	ctx := context.Background()
+	provider, err := NewProvider(ctx, cfg, nil)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	_, span := provider.Tracer().Start(ctx, "bootstrap.run")
+	span.End()
+	metrics, err := NewMetrics(provider.Meter())
+	if err != nil {
+		t.Fatalf("NewMetrics: %v", err)
+	}
+	metrics.RecordBootstrapDuration(ctx, 1.5)
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := provider.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if traceCollector.spans.Load() != 1 {
+		t.Errorf("trace collector received %d spans, want 1", traceCollector.spans.Load())
+	}
+	if !metricCollector.received("raymond.bootstrap.duration_seconds") {
+		t.Error("metric collector did not receive the bootstrap duration")
+	}
+}
+
+func TestProviderReadiness(t *testing.T) {
+	_, collectorAddr := serveMetricsCollector(t)
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		wantReady bool
+	}{
+		{"collector up", collectorAddr, true},
+		{"collector down", closedAddress(t), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.TelemetryConfig{
+				OTLPEndpoint:  tt.endpoint,
+				OTLPInsecure:  true,
+				ServiceName:   "raymond-test",
+				LogLevel:      "error",
+				HistogramType: "explicit",
+				TraceExporter: "otlp",
+				EnableMetrics: true,
+			}
+			provider, err := NewProvider(context.Background(), cfg, nil)
+			if err != nil {
+				t.Fatalf("NewProvider: %v", err)
+			}
+			t.Cleanup(func() {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				provider.Shutdown(ctx)
+			})
+
+			// An idle connection counts as ready, so make it dial as an export would
+			for _, conn := range provider.conns.conns {
+				conn.Connect()
+			}
+
+			deadline := time.Now().Add(5 * time.Second)
+			for {
+				ready, reason := provider.Readiness()
+				if ready == tt.wantReady {
+					if !ready && !strings.Contains(reason, tt.endpoint) {
+						t.Errorf("reason = %q, want it to name %s", reason, tt.endpoint)
+					}
+					return
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("Readiness = %v %q, want ready %v", ready, reason, tt.wantReady)
+				}
+				time.Sleep(20 * time.Millisecond)
+			}
+		})
+	}
+}
+
+// fakeLogsCollector accepts OTLP log exports and keeps the string attributes
+// of the records received, by body.
+type fakeLogsCollector struct {
+	collogspb.UnimplementedLogsServiceServer
+	mu      sync.Mutex
+	records map[string]map[string]string
+}
+
+func (c *fakeLogsCollector) Export(_ context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rl := range req.ResourceLogs {
+		for _, sl := range rl.ScopeLogs {
+			for _, record := range sl.LogRecords {
+				attrs := make(map[string]string)
+				for _, kv := range record.Attributes {
+					attrs[kv.Key] = kv.Value.GetStringValue()
+				}
+				c.records[record.Body.GetStringValue()] = attrs
+			}
+		}
+	}
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func (c *fakeLogsCollector) record(body string) (map[string]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	attrs, ok := c.records[body]
+	return attrs, ok
+}
+
+func TestNewProviderTruncatesLogAttributes(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	collector := &fakeLogsCollector{records: make(map[string]map[string]string)}
+	srv := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(srv, collector)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	cfg := &config.TelemetryConfig{
+		OTLPEndpoint:                 lis.Addr().String(),
+		OTLPInsecure:                 true,
+		ServiceName:                  "raymond-test",
+		LogLevel:                     "info",
+		LogSampleRate:                1,
+		HistogramType:                "explicit",
+		TraceExporter:                "otlp",
+		EnableLogs:                   true,
+		LogAttributeValueLengthLimit: 16,
+	}
+
+	ctx := context.Background()
+	provider, err := NewProvider(ctx, cfg, nil)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	provider.Logger().Info("schema rejected", "payload", strings.Repeat("x", 100), "subject", "orders")
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := provider.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	attrs, ok := collector.record("schema rejected")
+	if !ok {
+		t.Fatal("log record was not exported")
+	}
+	if want := strings.Repeat("x", 16) + truncationMarker; attrs["payload"] != want {
+		t.Errorf("payload = %q, want %q", attrs["payload"], want)
+	}
+	if attrs["subject"] != "orders" {
+		t.Errorf("subject = %q, want it kept whole", attrs["subject"])
+	}
+}
@@ -0,0 +1,23 @@
+package telemetry
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// WithServiceName returns res with service.name replaced by name, so one
+// signal can be attributed to a different service than the others. An empty
+// name returns res unchanged.
+func WithServiceName(res *resource.Resource, name string) (*resource.Resource, error) {
+	if name == "" {
+		return res, nil
+	}
+
+	merged, err := resource.Merge(res, resource.NewSchemaless(semconv.ServiceName(name)))
+	if err != nil {
+		return nil, fmt.Errorf("override service name: %w", err)
+	}
+	return merged, nil
+}
@@ -0,0 +1,44 @@
+package telemetry
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// serviceName returns res's service.name.
+func serviceName(res *resource.Resource) string {
+	value, _ := res.Set().Value(semconv.ServiceNameKey)
+	return value.AsString()
+}
+
+func TestWithServiceNamePerSignal(t *testing.T) {
+	base := resource.NewSchemaless(
+		semconv.ServiceName("raymond"),
+		semconv.ServiceNamespace("arc"),
+	)
+
+	traceRes, err := WithServiceName(base, "")
+	if err != nil {
+		t.Fatalf("WithServiceName trace: %v", err)
+	}
+	logRes, err := WithServiceName(base, "raymond-logs")
+	if err != nil {
+		t.Fatalf("WithServiceName log: %v", err)
+	}
+
+	if got := serviceName(traceRes); got != "raymond" {
+		t.Errorf("trace service.name = %q, want the main service name %q", got, "raymond")
+	}
+	if got := serviceName(logRes); got != "raymond-logs" {
+		t.Errorf("log service.name = %q, want %q", got, "raymond-logs")
+	}
+	// The override replaces only service.name, and leaves the shared resource alone
+	if namespace, _ := logRes.Set().Value(semconv.ServiceNamespaceKey); namespace.AsString() != "arc" {
+		t.Errorf("log service.namespace = %q, want %q", namespace.AsString(), "arc")
+	}
+	if got := serviceName(base); got != "raymond" {
+		t.Errorf("base service.name = %q after override, want %q", got, "raymond")
+	}
+}
@@ -0,0 +1,73 @@
+package telemetry
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// LogSampler keeps a fraction of debug and info log records to cut volume
+// from chatty loops; warnings and errors are always kept. The rate can be
+// changed at runtime, e.g. raised to 1 during an incident.
+type LogSampler struct {
+	rate atomic.Uint64 // math.Float64bits of the kept fraction
+}
+
+// NewLogSampler creates a sampler keeping rate (0–1) of low-severity records.
+func NewLogSampler(rate float64) *LogSampler {
+	s := &LogSampler{}
+	s.SetRate(rate)
+	return s
+}
+
+// SetRate sets the fraction of low-severity records kept, clamped to 0–1.
+// It takes effect for the next record.
+func (s *LogSampler) SetRate(rate float64) {
+	s.rate.Store(math.Float64bits(min(max(rate, 0), 1)))
+}
+
+// Rate returns the fraction of low-severity records kept.
+func (s *LogSampler) Rate() float64 {
+	return math.Float64frombits(s.rate.Load())
+}
+
+// keep reports whether a record at level passes the sampler.
+func (s *LogSampler) keep(level slog.Level) bool {
+	if level >= slog.LevelWarn {
+		return true
+	}
+	rate := s.Rate()
+	return rate >= 1 || rand.Float64() < rate
+}
+
+// Handler wraps next so that records go through the sampler first.
+func (s *LogSampler) Handler(next slog.Handler) slog.Handler {
+	return &samplingHandler{next: next, sampler: s}
+}
+
+// samplingHandler drops the records its sampler doesn't keep.
+type samplingHandler struct {
+	next    slog.Handler
+	sampler *LogSampler
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.sampler.keep(r.Level) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{next: h.next.WithGroup(name), sampler: h.sampler}
+}
@@ -0,0 +1,85 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// selfTestTimeout bounds how long the startup self-test waits for the collector.
+const selfTestTimeout = 5 * time.Second
+
+// selfTest emits a test span, metric and log, then checks that the collector
+// connection reaches Ready and that flushing the pipeline succeeds. It only
+// logs the outcome; a failed self-test never blocks startup. Either provider
+// may be nil when its signal is disabled.
+func selfTest(
+	ctx context.Context,
+	conn *grpc.ClientConn,
+	tracerProvider *sdktrace.TracerProvider,
+	meterProvider *sdkmetric.MeterProvider,
+	logger *slog.Logger,
+) {
+	ctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+	defer cancel()
+
+	var flushes []func(context.Context) error
+	if tracerProvider != nil {
+		_, span := tracerProvider.Tracer("telemetry.selftest").Start(ctx, "telemetry.selftest")
+		span.End()
+		flushes = append(flushes, tracerProvider.ForceFlush)
+	}
+
+	if meterProvider != nil {
+		counter, err := meterProvider.Meter("telemetry.selftest").Int64Counter(
+			"raymond.telemetry.selftest_total",
+		)
+		if err == nil {
+			counter.Add(ctx, 1)
+		}
+		flushes = append(flushes, meterProvider.ForceFlush)
+	}
+
+	logger.Info("telemetry self-test started", "timeout", selfTestTimeout.String())
+
+	if !waitForReady(ctx, conn) {
+		logger.Warn("telemetry self-test failed: collector connection never became ready",
+			"target", conn.Target(),
+			"state", conn.GetState().String())
+		return
+	}
+
+	var errs []error
+	for _, flush := range flushes {
+		errs = append(errs, flush(ctx))
+	}
+	if err := errors.Join(errs...); err != nil {
+		logger.Warn("telemetry self-test failed: export did not complete",
+			"target", conn.Target(),
+			"error", err)
+		return
+	}
+
+	logger.Info("telemetry self-test passed", "target", conn.Target())
+}
+
+// waitForReady triggers a connection attempt and waits until conn is Ready or
+// ctx expires.
+func waitForReady(ctx context.Context, conn *grpc.ClientConn) bool {
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return true
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return false
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// fakeTraceCollector accepts OTLP trace exports and counts the spans.
+type fakeTraceCollector struct {
+	coltracepb.UnimplementedTraceServiceServer
+	spans atomic.Int64
+}
+
+func (c *fakeTraceCollector) Export(_ context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans.Add(int64(len(ss.Spans)))
+		}
+	}
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// serveTraceCollector starts a fake OTLP trace collector and returns it with
+// its address.
+func serveTraceCollector(t *testing.T) (*fakeTraceCollector, string) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	collector := &fakeTraceCollector{}
+	srv := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(srv, collector)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return collector, lis.Addr().String()
+}
+
+// dialInsecure returns a client connection to addr without TLS.
+func dialInsecure(t *testing.T, addr string) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestSelfTestPassesAgainstCollector(t *testing.T) {
+	collector, addr := serveTraceCollector(t)
+
+	ctx := context.Background()
+	conn := dialInsecure(t, addr)
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("create exporter: %v", err)
+	}
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+
+	var logs bytes.Buffer
+	selfTest(ctx, conn, tracerProvider, nil, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	if !strings.Contains(logs.String(), "telemetry self-test passed") {
+		t.Errorf("self-test did not pass, logs:\n%s", logs.String())
+	}
+	if collector.spans.Load() == 0 {
+		t.Error("collector received no spans")
+	}
+}
+
+func TestSelfTestWarnsOnClosedEndpoint(t *testing.T) {
+	// Reserve a port, then close it so nothing is listening there
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	var logs bytes.Buffer
+	selfTest(ctx, dialInsecure(t, addr), nil, nil, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	if !strings.Contains(logs.String(), "level=WARN") ||
+		!strings.Contains(logs.String(), "collector connection never became ready") {
+		t.Errorf("expected a not-ready warning, logs:\n%s", logs.String())
+	}
+}
@@ -0,0 +1,230 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// multiSlogHandler is a custom slog.Handler that writes to multiple handlers.
+type multiSlogHandler struct {
+	handlers []slog.Handler
+}
+
+// NewMultiSlogHandler creates a handler that duplicates its writes to all the
+// provided handlers.
+func NewMultiSlogHandler(handlers ...slog.Handler) slog.Handler {
+	return &multiSlogHandler{handlers: handlers}
+}
+
+// Implement the slog.Handler interface for multiSlogHandler
+func (h *multiSlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	// Enabled if any of the underlying handlers are enabled.
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle passes the record to every handler, even if an earlier one fails, so
+// that an unreachable collector never suppresses console output. Errors from
+// all handlers are joined.
+func (h *multiSlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+func (h *multiSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newHandlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		newHandlers[i] = handler.WithAttrs(attrs)
+	}
+	return &multiSlogHandler{handlers: newHandlers}
+}
+func (h *multiSlogHandler) WithGroup(name string) slog.Handler {
+	newHandlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		newHandlers[i] = handler.WithGroup(name)
+	}
+	return &multiSlogHandler{handlers: newHandlers}
+}
+
+// truncationMarker is appended to attribute values cut to the length limit.
+const truncationMarker = "…(truncated)"
+
+// slogOtelHandler is a custom slog.Handler that sends log records to an OpenTelemetry Logger.
+type slogOtelHandler struct {
+	logger      log.Logger
+	level       slog.Leveler
+	maxValueLen int            // 0 means unlimited
+	attrs       []log.KeyValue // from WithAttrs, keys already group-prefixed
+	group       string         // dotted group path from WithGroup
+}
+
+// NewSlogOtelHandler creates a new handler that wraps the given OpenTelemetry Logger.
+// Records below level are dropped; a *slog.LevelVar lets the level change at
+// runtime, and nil means info. String attribute values longer than
+// maxValueLen are truncated; 0 disables the limit.
+func NewSlogOtelHandler(l log.Logger, level slog.Leveler, maxValueLen int) slog.Handler {
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &slogOtelHandler{logger: l, level: level, maxValueLen: maxValueLen}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *slogOtelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle processes the log record and sends it to the OpenTelemetry logger.
+func (h *slogOtelHandler) Handle(ctx context.Context, rec slog.Record) error {
+	logRecord := log.Record{}
+	logRecord.SetTimestamp(rec.Time)
+	logRecord.SetObservedTimestamp(time.Now())
+	logRecord.SetSeverity(slogLevelToOtelSeverity(rec.Level))
+	logRecord.SetBody(log.StringValue(rec.Message))
+	logRecord.AddAttributes(h.attrs...)
+	rec.Attrs(func(attr slog.Attr) bool {
+		h.appendAttr(h.group, attr, func(kv log.KeyValue) {
+			logRecord.AddAttributes(kv)
+		})
+		return true
+	})
+	h.logger.Emit(ctx, logRecord)
+	return nil
+}
+
+// appendAttr converts attr to an OTel key/value with its key prefixed by the
+// dotted WithGroup path and passes it to emit. Group-valued attributes become
+// nested maps, except groups with an empty key, which are inlined.
+func (h *slogOtelHandler) appendAttr(prefix string, attr slog.Attr, emit func(log.KeyValue)) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Key == "" && attr.Value.Kind() == slog.KindGroup {
+		for _, a := range attr.Value.Group() {
+			h.appendAttr(prefix, a, emit)
+		}
+		return
+	}
+
+	emit(log.KeyValue{Key: joinKey(prefix, attr.Key), Value: h.otelValue(attr.Value)})
+}
+
+// otelValue converts a slog value to the OTel log value of the matching type,
+// so numbers and booleans stay aggregatable. Durations become nanoseconds and
+// kinds without an OTel equivalent fall back to strings.
+func (h *slogOtelHandler) otelValue(v slog.Value) log.Value {
+	switch v.Kind() {
+	case slog.KindString:
+		return log.StringValue(h.truncate(v.String()))
+	case slog.KindInt64:
+		return log.Int64Value(v.Int64())
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= math.MaxInt64 {
+			return log.Int64Value(int64(u))
+		}
+	case slog.KindFloat64:
+		return log.Float64Value(v.Float64())
+	case slog.KindBool:
+		return log.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return log.Int64Value(v.Duration().Nanoseconds())
+	case slog.KindTime:
+		return log.StringValue(v.Time().Format(time.RFC3339Nano))
+	case slog.KindGroup:
+		kvs := make([]log.KeyValue, 0, len(v.Group()))
+		for _, a := range v.Group() {
+			h.appendAttr("", a, func(kv log.KeyValue) {
+				kvs = append(kvs, kv)
+			})
+		}
+		return log.MapValue(kvs...)
+	case slog.KindAny:
+		if b, ok := v.Any().([]byte); ok {
+			return log.BytesValue(b)
+		}
+	}
+	return log.StringValue(h.truncate(v.String()))
+}
+
+// joinKey joins a dotted group path and a key.
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// truncate cuts v to maxValueLen bytes on a rune boundary and marks it as truncated.
+func (h *slogOtelHandler) truncate(v string) string {
+	if h.maxValueLen <= 0 || len(v) <= h.maxValueLen {
+		return v
+	}
+	cut := h.maxValueLen
+	for cut > 0 && !utf8.RuneStart(v[cut]) {
+		cut--
+	}
+	return v[:cut] + truncationMarker
+}
+
+// WithAttrs returns a new handler that adds attrs, under the current group,
+// to every record.
+func (h *slogOtelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.attrs = slices.Clone(h.attrs)
+	for _, attr := range attrs {
+		h.appendAttr(h.group, attr, func(kv log.KeyValue) {
+			h2.attrs = append(h2.attrs, kv)
+		})
+	}
+	return &h2
+}
+
+// WithGroup returns a new handler that nests subsequent attributes under
+// name, so keys become "<group>.<key>".
+func (h *slogOtelHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.group = joinKey(h.group, name)
+	return &h2
+}
+
+// slogLevelToOtelSeverity converts slog levels to OpenTelemetry severity numbers.
+func slogLevelToOtelSeverity(l slog.Level) log.Severity {
+	switch l {
+	case slog.LevelDebug:
+		return log.SeverityDebug
+	case slog.LevelInfo:
+		return log.SeverityInfo
+	case slog.LevelWarn:
+		return log.SeverityWarn
+	case slog.LevelError:
+		return log.SeverityError
+	default:
+		return log.SeverityInfo
+	}
+}
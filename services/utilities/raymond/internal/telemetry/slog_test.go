@@ -0,0 +1,207 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+)
+
+// recordingLogger is an OTel logger that keeps every emitted record.
+type recordingLogger struct {
+	embedded.Logger
+	records []log.Record
+}
+
+func (l *recordingLogger) Emit(_ context.Context, record log.Record) {
+	l.records = append(l.records, record)
+}
+
+func (l *recordingLogger) Enabled(context.Context, log.EnabledParameters) bool {
+	return true
+}
+
+// attrs returns the string attributes of record, by key.
+func attrs(record log.Record) map[string]string {
+	byKey := make(map[string]string)
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		byKey[kv.Key] = kv.Value.AsString()
+		return true
+	})
+	return byKey
+}
+
+func TestSlogOtelHandlerTruncatesLongValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		value string
+		want  string
+	}{
+		{"under limit", 16, "short", "short"},
+		{"at limit", 5, "exact", "exact"},
+		{"over limit", 8, strings.Repeat("x", 100), "xxxxxxxx" + truncationMarker},
+		{"rune boundary", 4, "aaaé", "aaa" + truncationMarker},
+		{"unlimited", 0, strings.Repeat("x", 100), strings.Repeat("x", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otelLogger := &recordingLogger{}
+			logger := slog.New(NewSlogOtelHandler(otelLogger, nil, tt.limit))
+			logger.Info("payload rejected", "payload", tt.value)
+
+			if len(otelLogger.records) != 1 {
+				t.Fatalf("emitted %d records, want 1", len(otelLogger.records))
+			}
+			if got := attrs(otelLogger.records[0])["payload"]; got != tt.want {
+				t.Errorf("payload = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// failingHandler fails every record it is given.
+type failingHandler struct {
+	slog.Handler
+	err error
+}
+
+func (h failingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h failingHandler) Handle(context.Context, slog.Record) error { return h.err }
+
+func TestMultiSlogHandlerContinuesAfterFailure(t *testing.T) {
+	collectorDown := errors.New("collector unavailable")
+	var console bytes.Buffer
+	handler := NewMultiSlogHandler(
+		failingHandler{err: collectorDown},
+		slog.NewTextHandler(&console, nil),
+	)
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "bootstrap started", 0)
+	err := handler.Handle(context.Background(), record)
+
+	if !errors.Is(err, collectorDown) {
+		t.Errorf("Handle error = %v, want %v", err, collectorDown)
+	}
+	if !strings.Contains(console.String(), "bootstrap started") {
+		t.Errorf("console handler did not receive the record, got %q", console.String())
+	}
+}
+
+func TestLogFieldReplacer(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{
+		ReplaceAttr: logFieldReplacer(map[string]string{"msg": "message", "level": "severity"}),
+	}))
+	logger.WithGroup("probe").Warn("dependency unhealthy", "msg", "nested keys keep their name")
+
+	var entry map[string]any
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("decode log entry %q: %v", out.String(), err)
+	}
+	if entry["message"] != "dependency unhealthy" || entry["severity"] != "WARN" {
+		t.Errorf("entry = %v, want message and severity keys", entry)
+	}
+	if _, ok := entry["msg"]; ok {
+		t.Errorf("entry = %v, still has the msg key", entry)
+	}
+	if group, _ := entry["probe"].(map[string]any); group["msg"] != "nested keys keep their name" {
+		t.Errorf("probe group = %v, want its msg key unchanged", entry["probe"])
+	}
+}
+
+// values returns the attributes of record, by key.
+func values(record log.Record) map[string]log.Value {
+	byKey := make(map[string]log.Value)
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		byKey[kv.Key] = kv.Value
+		return true
+	})
+	return byKey
+}
+
+func TestSlogOtelHandlerPreservesValueTypes(t *testing.T) {
+	otelLogger := &recordingLogger{}
+	logger := slog.New(NewSlogOtelHandler(otelLogger, nil, 0))
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	logger.Info("probe finished",
+		slog.String("service", "postgres"),
+		slog.Int("attempt", 3),
+		slog.Uint64("bytes", 512),
+		slog.Float64("latency_ms", 12.5),
+		slog.Bool("critical", true),
+		slog.Duration("timeout", 2*time.Second),
+		slog.Time("checked_at", at),
+		slog.Group("http", slog.String("method", "GET"), slog.Int("status", 200)),
+		slog.Any("payload", []byte("raw")),
+		slog.Any("err", errors.New("connection refused")),
+	)
+
+	if len(otelLogger.records) != 1 {
+		t.Fatalf("emitted %d records, want 1", len(otelLogger.records))
+	}
+	got := values(otelLogger.records[0])
+	tests := []struct {
+		key  string
+		want log.Value
+	}{
+		{"service", log.StringValue("postgres")},
+		{"attempt", log.Int64Value(3)},
+		{"bytes", log.Int64Value(512)},
+		{"latency_ms", log.Float64Value(12.5)},
+		{"critical", log.BoolValue(true)},
+		{"timeout", log.Int64Value(int64(2 * time.Second))},
+		{"checked_at", log.StringValue("2026-10-16T12:00:00Z")},
+		{"http", log.MapValue(log.String("method", "GET"), log.Int64("status", 200))},
+		{"payload", log.BytesValue([]byte("raw"))},
+		{"err", log.StringValue("connection refused")},
+	}
+	for _, tt := range tests {
+		if v, ok := got[tt.key]; !ok || !v.Equal(tt.want) {
+			t.Errorf("%s = %v (%s), want %v (%s)", tt.key, v, v.Kind(), tt.want, tt.want.Kind())
+		}
+	}
+}
+
+func TestSlogOtelHandlerFollowsLevelVar(t *testing.T) {
+	otelLogger := &recordingLogger{}
+	level := new(slog.LevelVar)
+	logger := slog.New(NewSlogOtelHandler(otelLogger, level, 0))
+
+	emitted := func() []string {
+		otelLogger.records = nil
+		logger.Debug("debug")
+		logger.Info("info")
+		logger.Warn("warn")
+		var bodies []string
+		for _, record := range otelLogger.records {
+			bodies = append(bodies, record.Body().AsString())
+		}
+		return bodies
+	}
+
+	// The level changes at runtime, as on a config reload
+	for _, tt := range []struct {
+		level slog.Level
+		want  string
+	}{
+		{slog.LevelInfo, "[info warn]"},
+		{slog.LevelDebug, "[debug info warn]"},
+		{slog.LevelWarn, "[warn]"},
+	} {
+		level.Set(tt.level)
+		if got := fmt.Sprint(emitted()); got != tt.want {
+			t.Errorf("at level %s exported %s, want %s", tt.level, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package telemetry
+
+import (
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// NewTestMetrics returns Metrics backed by an in-memory meter provider, along
+// with the manual reader that collects them, so tests can assert on recorded
+// values without a collector.
+func NewTestMetrics() (*Metrics, *sdkmetric.ManualReader, error) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	metrics, err := NewMetrics(provider.Meter("test"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return metrics, reader, nil
+}
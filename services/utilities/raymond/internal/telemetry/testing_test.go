@@ -0,0 +1,55 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewMetricsWithNoopMeter(t *testing.T) {
+	metrics, err := NewMetrics(metricnoop.NewMeterProvider().Meter("test"))
+	if err != nil {
+		t.Fatalf("NewMetrics: %v", err)
+	}
+
+	// None of these should panic without a collector behind the meter
+	ctx := context.Background()
+	metrics.RecordBootstrapDuration(ctx, 1.5)
+	metrics.RecordBootstrapPhase(ctx, "initialize_nats", 0.2)
+	metrics.RecordBootstrapError(ctx, "initialize_nats")
+	metrics.RecordBootstrapSuccess(ctx, time.Now())
+	metrics.RecordProbe(ctx, "postgres", 0.003)
+	metrics.RecordOutage(ctx, "postgres", 12)
+	metrics.RecordConsumerPending(ctx, "events", "indexer", 7)
+	metrics.RecordHTTPRequest(ctx, "GET", "/health", 200, 0.01)
+	metrics.RecordBreakerStateChange(ctx, "postgres", "closed", "open", 2)
+}
+
+func TestNewTestMetricsRecordsBootstrapError(t *testing.T) {
+	metrics, reader, err := NewTestMetrics()
+	if err != nil {
+		t.Fatalf("NewTestMetrics: %v", err)
+	}
+
+	ctx := context.Background()
+	metrics.RecordBootstrapError(ctx, "migrate_database")
+	metrics.RecordBootstrapError(ctx, "migrate_database")
+	metrics.RecordBootstrapError(ctx, "initialize_pulsar")
+
+	m, ok := collect(t, reader)["raymond.bootstrap.errors_total"]
+	if !ok {
+		t.Fatal("raymond.bootstrap.errors_total not recorded")
+	}
+	byPhase := make(map[string]int64)
+	for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		phase, _ := point.Attributes.Value(attribute.Key("phase"))
+		byPhase[phase.AsString()] = point.Value
+	}
+	if byPhase["migrate_database"] != 2 || byPhase["initialize_pulsar"] != 1 {
+		t.Errorf("errors_total by phase = %v, want migrate_database 2, initialize_pulsar 1", byPhase)
+	}
+}
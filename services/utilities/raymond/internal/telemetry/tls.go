@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+)
+
+// collectorTLSConfig builds the TLS configuration for the collector
+// connection from base, the service-wide settings. The CA bundle replaces the
+// system pool when otlp_ca_file is set, and a client certificate is presented
+// for mTLS when otlp_client_cert and otlp_client_key are set.
+func collectorTLSConfig(cfg *config.TelemetryConfig, base *tls.Config) (*tls.Config, error) {
+	tlsCfg := base.Clone()
+	if tlsCfg == nil {
+		tlsCfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if cfg.OTLPCAFile != "" {
+		pem, err := os.ReadFile(cfg.OTLPCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read otlp CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("otlp CA file %s contains no PEM certificates", cfg.OTLPCAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.OTLPClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.OTLPClientCert, cfg.OTLPClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("load otlp client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.OTLPServerName != "" {
+		tlsCfg.ServerName = cfg.OTLPServerName
+	}
+
+	return tlsCfg, nil
+}
@@ -2,22 +2,28 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/arc-framework/platform-spike/services/raymond/internal/config"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/supervisor"
+	"github.com/arc-framework/platform-spike/services/raymond/internal/telemetry"
+	pkgerrors "github.com/arc-framework/platform-spike/services/raymond/pkg/errors"
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
@@ -28,110 +34,14 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-// multiSlogHandler is a custom slog.Handler that writes to multiple handlers.
-type multiSlogHandler struct {
-	handlers []slog.Handler
-}
-
-// NewMultiSlogHandler creates a handler that duplicates its writes to all the
-// provided handlers.
-func NewMultiSlogHandler(handlers ...slog.Handler) slog.Handler {
-	return &multiSlogHandler{handlers: handlers}
-}
-
-// Implement the slog.Handler interface for multiSlogHandler
-func (h *multiSlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
-	// Enabled if any of the underlying handlers are enabled.
-	for _, handler := range h.handlers {
-		if handler.Enabled(ctx, level) {
-			return true
-		}
-	}
-	return false
-}
-func (h *multiSlogHandler) Handle(ctx context.Context, r slog.Record) error {
-	for _, handler := range h.handlers {
-		if err := handler.Handle(ctx, r); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-func (h *multiSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	newHandlers := make([]slog.Handler, len(h.handlers))
-	for i, handler := range h.handlers {
-		newHandlers[i] = handler.WithAttrs(attrs)
-	}
-	return &multiSlogHandler{handlers: newHandlers}
-}
-func (h *multiSlogHandler) WithGroup(name string) slog.Handler {
-	newHandlers := make([]slog.Handler, len(h.handlers))
-	for i, handler := range h.handlers {
-		newHandlers[i] = handler.WithGroup(name)
-	}
-	return &multiSlogHandler{handlers: newHandlers}
-}
-
-// slogOtelHandler is a custom slog.Handler that sends log records to an OpenTelemetry Logger.
-type slogOtelHandler struct {
-	logger log.Logger
-}
-
-// NewSlogOtelHandler creates a new handler that wraps the given OpenTelemetry Logger.
-func NewSlogOtelHandler(l log.Logger) slog.Handler {
-	return &slogOtelHandler{logger: l}
-}
-
-// Enabled reports whether the handler handles records at the given level.
-func (h *slogOtelHandler) Enabled(_ context.Context, level slog.Level) bool {
-	return level >= slog.LevelInfo // Adjust level as needed
-}
-
-// Handle processes the log record and sends it to the OpenTelemetry logger.
-func (h *slogOtelHandler) Handle(ctx context.Context, rec slog.Record) error {
-	logRecord := log.Record{}
-	logRecord.SetTimestamp(rec.Time)
-	logRecord.SetObservedTimestamp(time.Now())
-	logRecord.SetSeverity(slogLevelToOtelSeverity(rec.Level))
-	logRecord.SetBody(log.StringValue(rec.Message))
-	rec.Attrs(func(attr slog.Attr) bool {
-		logRecord.AddAttributes(log.String(attr.Key, attr.Value.String()))
-		return true
-	})
-	h.logger.Emit(ctx, logRecord)
-	return nil
-}
-
-// WithAttrs returns a new handler with the given attributes.
-func (h *slogOtelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	// For simplicity, this example doesn't handle nested attributes.
-	// A production-ready handler would need to manage these.
-	return h
-}
-
-// WithGroup returns a new handler with the given group name.
-func (h *slogOtelHandler) WithGroup(name string) slog.Handler {
-	// For simplicity, this example doesn't handle groups.
-	return h
-}
-
-// slogLevelToOtelSeverity converts slog levels to OpenTelemetry severity numbers.
-func slogLevelToOtelSeverity(l slog.Level) log.Severity {
-	switch l {
-	case slog.LevelDebug:
-		return log.SeverityDebug
-	case slog.LevelInfo:
-		return log.SeverityInfo
-	case slog.LevelWarn:
-		return log.SeverityWarn
-	case slog.LevelError:
-		return log.SeverityError
-	default:
-		return log.SeverityInfo
-	}
+// fatal logs the error and exits with the code for its category.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(pkgerrors.ExitCode(err))
 }
 
 // newOtelProvider initializes and configures the OpenTelemetry SDK, returning a shutdown function.
@@ -142,6 +52,7 @@ func newOtelProvider(ctx context.Context) (func(context.Context) error, error) {
 		resource.WithProcess(),
 		resource.WithTelemetrySDK(),
 		resource.WithHost(),
+		resource.WithAttributes(telemetry.BuildInfoAttributes()...),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource: %w", err)
@@ -159,10 +70,14 @@ func newOtelProvider(ctx context.Context) (func(context.Context) error, error) {
 	}
 
 	// Conditionally set transport security based on the OTEL_EXPORTER_OTLP_INSECURE env var.
-	dialOptions := []grpc.DialOption{grpc.WithBlock()}
+	// The connection is established lazily, so an unreachable collector never
+	// blocks startup; exporters retry in the background.
+	var dialOptions []grpc.DialOption
 	if os.Getenv("OTEL_EXPORTER_OTLP_INSECURE") == "true" {
 		// This is the crucial part: explicitly tell gRPC not to use TLS.
 		dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(legacyTLSConfig())))
 	}
 
 	conn, err := grpc.NewClient(endpoint, dialOptions...)
@@ -170,85 +85,151 @@ func newOtelProvider(ctx context.Context) (func(context.Context) error, error) {
 		return nil, fmt.Errorf("failed to create gRPC connection to collector: %w", err)
 	}
 
+	// Each signal can be turned off with the standard OTEL_<SIGNAL>_EXPORTER=none
+	// env var, in which case no exporter or provider is created for it.
+
 	// --- TRACER SETUP ---
 	// The exporter will be configured using environment variables:
 	// - OTEL_EXPORTER_OTLP_ENDPOINT
 	// - OTEL_EXPORTER_OTLP_INSECURE
-	traceExporter, err := otlptracegrpc.New(ctx,
-		otlptracegrpc.WithGRPCConn(conn),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
-	}
+	var tracerProvider *sdktrace.TracerProvider
+	if signalEnabled("TRACES") {
+		traceExporter, err := otlptracegrpc.New(ctx,
+			otlptracegrpc.WithGRPCConn(conn),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+		}
 
-	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-		sdktrace.WithResource(res),
-		// Use a Batcher for efficiency, but a SimpleSpanProcessor for local dev
-		// can be useful to see traces immediately.
-		sdktrace.WithBatcher(traceExporter, sdktrace.WithBatchTimeout(1*time.Second)),
-	)
-	otel.SetTracerProvider(tracerProvider)
+		traceRes, err := telemetry.WithServiceName(res, os.Getenv("OTEL_TRACES_SERVICE_NAME"))
+		if err != nil {
+			return nil, err
+		}
+
+		// OTEL_TRACES_SAMPLER_ARG sets the fraction of new traces sampled
+		sampleRatio := 1.0
+		if v := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); v != "" {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 && parsed <= 1 {
+				sampleRatio = parsed
+			} else {
+				slog.Warn("invalid OTEL_TRACES_SAMPLER_ARG, sampling every trace", "value", v)
+			}
+		}
+
+		tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithSampler(telemetry.Sampler(sampleRatio)),
+			sdktrace.WithResource(traceRes),
+			// Use a Batcher for efficiency, but a SimpleSpanProcessor for local dev
+			// can be useful to see traces immediately.
+			sdktrace.WithBatcher(traceExporter, sdktrace.WithBatchTimeout(1*time.Second)),
+		)
+		otel.SetTracerProvider(tracerProvider)
+	}
 	otel.SetTextMapPropagator(propagation.TraceContext{})
 
 	// --- METER SETUP ---
-	metricExporter, err := otlpmetricgrpc.New(ctx,
-		// The exporter will be configured using the same environment variables.
-		otlpmetricgrpc.WithGRPCConn(conn),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create metrics exporter: %w", err)
-	}
+	var meterProvider *sdkmetric.MeterProvider
+	if signalEnabled("METRICS") {
+		metricExporter, err := otlpmetricgrpc.New(ctx,
+			// The exporter will be configured using the same environment variables.
+			otlpmetricgrpc.WithGRPCConn(conn),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metrics exporter: %w", err)
+		}
 
-	meterProvider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(5*time.Second))),
-		sdkmetric.WithResource(res),
-	)
-	otel.SetMeterProvider(meterProvider)
+		meterProvider = sdkmetric.NewMeterProvider(
+			sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(5*time.Second))),
+			sdkmetric.WithResource(res),
+		)
+		otel.SetMeterProvider(meterProvider)
+	}
 
 	// --- LOGGER SETUP ---
 	// This is the missing piece. We set up a third exporter for logs.
-	logExporter, err := otlploggrpc.New(ctx,
-		// The exporter will be configured using the same environment variables.
-		otlploggrpc.WithGRPCConn(conn),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create log exporter: %w", err)
-	}
+	consoleHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
+	var loggerProvider *sdklog.LoggerProvider
+	if signalEnabled("LOGS") {
+		logExporter, err := otlploggrpc.New(ctx,
+			// The exporter will be configured using the same environment variables.
+			otlploggrpc.WithGRPCConn(conn),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create log exporter: %w", err)
+		}
 
-	loggerProvider := sdklog.NewLoggerProvider(
-		sdklog.WithResource(res),
-		sdklog.WithProcessor(sdklog.NewBatchProcessor(logExporter)),
-	)
-	global.SetLoggerProvider(loggerProvider)
+		logRes, err := telemetry.WithServiceName(res, os.Getenv("OTEL_LOGS_SERVICE_NAME"))
+		if err != nil {
+			return nil, err
+		}
 
-	// Create a multi-handler to log to both the console (for local dev) and OTel.
-	otelHandler := NewSlogOtelHandler(loggerProvider.Logger("main"))
-	consoleHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
+		loggerProvider = sdklog.NewLoggerProvider(
+			sdklog.WithResource(logRes),
+			sdklog.WithProcessor(sdklog.NewBatchProcessor(logExporter)),
+		)
+		global.SetLoggerProvider(loggerProvider)
+
+		// Create a multi-handler to log to both the console (for local dev) and OTel.
+		// Cap attribute values so large payloads don't get the record rejected by the collector.
+		maxValueLen := 0
+		if v := os.Getenv("OTEL_LOGRECORD_ATTRIBUTE_VALUE_LENGTH_LIMIT"); v != "" {
+			if parsed, err := strconv.Atoi(v); err == nil {
+				maxValueLen = parsed
+			} else {
+				slog.Warn("invalid OTEL_LOGRECORD_ATTRIBUTE_VALUE_LENGTH_LIMIT, ignoring", "value", v)
+			}
+		}
+		otelHandler := telemetry.NewSlogOtelHandler(loggerProvider.Logger("main"), slog.LevelInfo, maxValueLen)
 
-	// Set the default logger to use the multi-handler.
-	slog.SetDefault(slog.New(NewMultiSlogHandler(consoleHandler, otelHandler)))
+		// Set the default logger to use the multi-handler.
+		slog.SetDefault(slog.New(telemetry.NewMultiSlogHandler(consoleHandler, otelHandler)))
+	} else {
+		slog.SetDefault(slog.New(consoleHandler))
+	}
 
 	// Return a function that gracefully shuts down both providers.
 	return func(ctx context.Context) error {
-		// Close the gRPC connection.
-		if err := conn.Close(); err != nil {
-			slog.Error("failed to close gRPC connection", "error", err)
+		// The gRPC connection is closed last so the final flushes can still export.
+		defer func() {
+			if err := conn.Close(); err != nil {
+				slog.Error("failed to close gRPC connection", "error", err)
+			}
+		}()
+
+		if meterProvider != nil {
+			// Export whatever the periodic reader hasn't picked up yet, e.g. the
+			// final bootstrap durations recorded just before shutdown.
+			if err := meterProvider.ForceFlush(ctx); err != nil {
+				slog.Error("failed to flush metrics", "error", err)
+			}
 		}
+
 		// Shutdown providers in reverse order of initialization: logger, meter, tracer.
-		if err := loggerProvider.Shutdown(ctx); err != nil {
-			return fmt.Errorf("failed to shutdown LoggerProvider: %w", err)
+		if loggerProvider != nil {
+			if err := loggerProvider.Shutdown(ctx); err != nil {
+				return fmt.Errorf("failed to shutdown LoggerProvider: %w", err)
+			}
 		}
-		if err := meterProvider.Shutdown(ctx); err != nil {
-			return fmt.Errorf("failed to shutdown MeterProvider: %w", err)
+		if meterProvider != nil {
+			if err := meterProvider.Shutdown(ctx); err != nil {
+				return fmt.Errorf("failed to shutdown MeterProvider: %w", err)
+			}
 		}
-		if err := tracerProvider.Shutdown(ctx); err != nil {
-			return fmt.Errorf("failed to shutdown TracerProvider: %w", err)
+		if tracerProvider != nil {
+			if err := tracerProvider.Shutdown(ctx); err != nil {
+				return fmt.Errorf("failed to shutdown TracerProvider: %w", err)
+			}
 		}
 		return nil
 	}, nil
 }
 
+// signalEnabled reports whether a telemetry signal (TRACES, METRICS or LOGS)
+// should be exported, following the OTEL_<SIGNAL>_EXPORTER=none convention.
+func signalEnabled(signal string) bool {
+	return os.Getenv("OTEL_"+signal+"_EXPORTER") != "none"
+}
+
 // App holds the application's dependencies.
 type App struct {
 	tracer         trace.Tracer
@@ -258,7 +239,8 @@ type App struct {
 }
 
 // runBackgroundWorker starts a ticker to perform a unit of work at a regular interval.
-func (a *App) runBackgroundWorker(ctx context.Context) {
+// It beats hb after every unit so a stuck iteration can be detected and restarted.
+func (a *App) runBackgroundWorker(ctx context.Context, hb *supervisor.Heartbeat) {
 	// Start a ticker to run the work every 10 seconds.
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
@@ -280,6 +262,7 @@ func (a *App) runBackgroundWorker(ctx context.Context) {
 			}
 			slog.InfoContext(workCtx, "Background work complete.")
 			span.End()
+			hb.Beat()
 		case <-ctx.Done():
 			slog.Info("Background worker stopping.")
 			return
@@ -351,34 +334,115 @@ func probeTCP(ctx context.Context, addr string, timeout time.Duration) checkResu
 	return checkResult{OK: true, LatencyMS: lat}
 }
 
+// legacyTLSConfig returns the base TLS configuration for outbound connections,
+// with the minimum version from MIN_TLS_VERSION (1.2 or 1.3, default 1.2).
+func legacyTLSConfig() *tls.Config {
+	return config.SecurityConfig{MinTLSVersion: os.Getenv("MIN_TLS_VERSION")}.TLSConfig()
+}
+
+// probeClient is shared by the HTTP probes, so they reuse connections instead
+// of building a transport for every request. Each probe's timeout comes from
+// its context.
+var probeClient = sync.OnceValue(func() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = legacyTLSConfig()
+	return &http.Client{Transport: transport}
+})
+
 // probeHTTP performs an HTTP GET and considers 2xx success
 func probeHTTP(ctx context.Context, url string, timeout time.Duration) checkResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	start := time.Now()
 	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	client := &http.Client{Timeout: timeout}
-	resp, err := client.Do(req)
+	resp, err := probeClient().Do(req)
 	lat := time.Since(start).Milliseconds()
 	if err != nil {
 		return checkResult{OK: false, LatencyMS: lat, Error: err.Error()}
 	}
 	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) // Drain so the connection can be reused
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		return checkResult{OK: true, LatencyMS: lat}
 	}
 	return checkResult{OK: false, LatencyMS: lat, Error: fmt.Sprintf("status=%d", resp.StatusCode)}
 }
 
+// runChecks runs checks concurrently and returns their results once all have
+// finished or ctx is done, whichever comes first. Checks still running at the
+// deadline are reported as failed, so a check that ignores ctx can't hold up
+// the response.
+func runChecks(ctx context.Context, checks map[string]func(context.Context) checkResult) map[string]checkResult {
+	type namedResult struct {
+		name   string
+		result checkResult
+	}
+
+	start := time.Now()
+	done := make(chan namedResult, len(checks)) // buffered so late checks never block
+	for name, check := range checks {
+		go func() {
+			done <- namedResult{name: name, result: check(ctx)}
+		}()
+	}
+
+	results := make(map[string]checkResult, len(checks))
+	for len(results) < len(checks) {
+		select {
+		case r := <-done:
+			results[r.name] = r.result
+		case <-ctx.Done():
+			for name := range checks {
+				if _, ok := results[name]; !ok {
+					results[name] = checkResult{
+						OK:        false,
+						LatencyMS: time.Since(start).Milliseconds(),
+						Error:     fmt.Sprintf("timed out: %v", ctx.Err()),
+					}
+				}
+			}
+		}
+	}
+	return results
+}
+
+// notifyShutdown returns a context that is canceled on the first signal
+// received from signals. A second signal calls hardStop.
+func notifyShutdown(parent context.Context, signals <-chan os.Signal, hardStop func()) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		select {
+		case <-signals:
+		case <-ctx.Done():
+			return
+		}
+		slog.Info("interrupt received, shutting down gracefully (interrupt again to force)")
+		cancel()
+
+		<-signals
+		hardStop()
+	}()
+	return ctx, cancel
+}
+
 func main() {
 	slog.Info("Starting arc-raymond-services (utility runner)...")
 
-	// Set up a context that is canceled on an interrupt signal.
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	// Set up a context that is canceled on an interrupt signal. A second
+	// interrupt skips the graceful shutdown and exits immediately.
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+	ctx, stop := notifyShutdown(context.Background(), signals, func() {
+		slog.Warn("second interrupt received, forcing exit")
+		os.Exit(pkgerrors.ExitInterrupted)
+	})
 	defer stop()
 
 	shutdown, err := newOtelProvider(ctx)
 	if err != nil {
-		slog.Error("failed to set up OpenTelemetry", "error", err)
-		os.Exit(1)
+		fatal("failed to set up OpenTelemetry", fmt.Errorf("%w: %w", pkgerrors.ErrTelemetrySetup, err))
 	}
 	// Defer the shutdown function to be called when main exits.
 	defer func() {
@@ -398,13 +462,11 @@ func main() {
 	// Create metrics once and reuse them to be more efficient.
 	backgroundRuns, err := meter.Int64Counter("background.runs.count", metric.WithDescription("The number of times the background worker ran."))
 	if err != nil {
-		slog.Error("failed to create background runs counter", "error", err)
-		os.Exit(1)
+		fatal("failed to create background runs counter", fmt.Errorf("%w: %w", pkgerrors.ErrTelemetrySetup, err))
 	}
 	onDemandRuns, err := meter.Int64Counter("ondemand.runs.count", metric.WithDescription("The number of times the on-demand endpoint was called."))
 	if err != nil {
-		slog.Error("failed to create on-demand runs counter", "error", err)
-		os.Exit(1)
+		fatal("failed to create on-demand runs counter", fmt.Errorf("%w: %w", pkgerrors.ErrTelemetrySetup, err))
 	}
 
 	// Create our application struct for the HTTP server.
@@ -415,8 +477,19 @@ func main() {
 		onDemandRuns:   onDemandRuns,
 	}
 
-	// Start the background worker in a goroutine.
-	go app.runBackgroundWorker(ctx)
+	// Start the background worker, restarting it if it stops beating for
+	// WORKER_STALL_THRESHOLD (default 1m, 0 disables).
+	stallThreshold := time.Minute
+	if v := os.Getenv("WORKER_STALL_THRESHOLD"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil {
+			stallThreshold = parsed
+		} else {
+			slog.Warn("ignoring invalid WORKER_STALL_THRESHOLD", "value", v, "error", err)
+		}
+	}
+	workers := supervisor.New(slog.Default(), stallThreshold)
+	workers.Go(ctx, "background_worker", app.runBackgroundWorker)
+	go workers.Run(ctx)
 
 	// --- HTTP Server (gin) ---
 	servicePort := os.Getenv("SERVICE_PORT")
@@ -493,49 +566,24 @@ func main() {
 		}
 		timeout := time.Duration(checkTimeoutMs) * time.Millisecond
 
-		// concurrent probes
-		var wg sync.WaitGroup
-		results := map[string]checkResult{}
-		mu := sync.Mutex{}
+		// concurrent probes, bounded by the overall deadline
 		ctxTimeout, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
-		checks := map[string]func(){
-			"postgres": func() {
-				res := probeTCP(ctxTimeout, net.JoinHostPort(postgresHost, postgresPort), timeout)
-				mu.Lock()
-				results["postgres"] = res
-				mu.Unlock()
-				wg.Done()
+		results := runChecks(ctxTimeout, map[string]func(context.Context) checkResult{
+			"postgres": func(ctx context.Context) checkResult {
+				return probeTCP(ctx, net.JoinHostPort(postgresHost, postgresPort), timeout)
 			},
-			"redis": func() {
-				res := probeTCP(ctxTimeout, net.JoinHostPort(redisHost, redisPort), timeout)
-				mu.Lock()
-				results["redis"] = res
-				mu.Unlock()
-				wg.Done()
+			"redis": func(ctx context.Context) checkResult {
+				return probeTCP(ctx, net.JoinHostPort(redisHost, redisPort), timeout)
 			},
-			"infisical": func() {
-				res := probeHTTP(ctxTimeout, infisicalURL, timeout)
-				mu.Lock()
-				results["infisical"] = res
-				mu.Unlock()
-				wg.Done()
+			"infisical": func(ctx context.Context) checkResult {
+				return probeHTTP(ctx, infisicalURL, timeout)
 			},
-			"unleash": func() {
-				res := probeHTTP(ctxTimeout, unleashURL, timeout)
-				mu.Lock()
-				results["unleash"] = res
-				mu.Unlock()
-				wg.Done()
+			"unleash": func(ctx context.Context) checkResult {
+				return probeHTTP(ctx, unleashURL, timeout)
 			},
-		}
-
-		wg.Add(len(checks))
-		for _, fn := range checks {
-			go fn()
-		}
-		wg.Wait()
+		})
 
 		// aggregate
 		allOK := true
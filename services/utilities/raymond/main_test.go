@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNewOtelProviderUnreachableCollector(t *testing.T) {
+	// The .invalid TLD never resolves
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "otel-collector.invalid:4317")
+	t.Setenv("OTEL_EXPORTER_OTLP_INSECURE", "true")
+	defaultLogger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	type result struct {
+		shutdown func(context.Context) error
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		shutdown, err := newOtelProvider(context.Background())
+		done <- result{shutdown, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			t.Fatalf("newOtelProvider: %v", r.err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		r.shutdown(ctx) // flushing to the unreachable collector is expected to fail
+	case <-time.After(5 * time.Second):
+		t.Fatal("newOtelProvider blocked on the unreachable collector")
+	}
+}
+
+func TestRunChecksReturnsAtDeadline(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+
+	checks := map[string]func(context.Context) checkResult{
+		"postgres": func(context.Context) checkResult { return checkResult{OK: true} },
+		// Ignores ctx entirely, like a driver call without a deadline
+		"redis": func(context.Context) checkResult {
+			<-hang
+			return checkResult{OK: true}
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	results := runChecks(ctx, checks)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("runChecks took %s, want it to return at the 100ms deadline", elapsed)
+	}
+
+	if !results["postgres"].OK {
+		t.Errorf("postgres = %+v, want ok", results["postgres"])
+	}
+	if redis := results["redis"]; redis.OK || !strings.Contains(redis.Error, "timed out") {
+		t.Errorf("redis = %+v, want a timed out failure", redis)
+	}
+}
+
+func TestNotifyShutdownSecondSignalForcesStop(t *testing.T) {
+	signals := make(chan os.Signal, 2)
+	hardStopped := make(chan struct{})
+	ctx, cancel := notifyShutdown(context.Background(), signals, func() { close(hardStopped) })
+	defer cancel()
+
+	signals <- syscall.SIGINT
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("first interrupt did not start the graceful shutdown")
+	}
+	select {
+	case <-hardStopped:
+		t.Fatal("first interrupt forced a hard stop")
+	default:
+	}
+
+	signals <- syscall.SIGINT
+	select {
+	case <-hardStopped:
+	case <-time.After(time.Second):
+		t.Fatal("second interrupt did not force a hard stop")
+	}
+}
@@ -15,13 +15,65 @@ var (
 	// ErrBootstrapFailed is returned when the bootstrap process fails.
 	ErrBootstrapFailed = errors.New("bootstrap process failed")
 
+	// ErrBootstrapDeadline is returned when bootstrap exceeds its total duration budget.
+	ErrBootstrapDeadline = errors.New("bootstrap exceeded maximum total duration")
+
 	// ErrConfigInvalid is returned when configuration validation fails.
 	ErrConfigInvalid = errors.New("invalid configuration")
 
 	// ErrCircuitOpen is returned when a circuit breaker is open.
 	ErrCircuitOpen = errors.New("circuit breaker open")
+
+	// ErrTelemetrySetup is returned when the telemetry pipeline cannot be initialized.
+	ErrTelemetrySetup = errors.New("telemetry setup failed")
+)
+
+// Code identifies the category of a failure.
+type Code string
+
+const (
+	// CodeUnknown is used for errors that don't match any known category.
+	CodeUnknown Code = "unknown"
+
+	// CodeConfig indicates invalid or unreadable configuration.
+	CodeConfig Code = "config"
+
+	// CodeDependencyTimeout indicates a dependency never became healthy.
+	CodeDependencyTimeout Code = "dependency_timeout"
+
+	// CodeProvisioning indicates a bootstrap phase failed to provision resources.
+	CodeProvisioning Code = "provisioning"
+
+	// CodeTelemetry indicates the telemetry pipeline could not be set up.
+	CodeTelemetry Code = "telemetry"
 )
 
+// CodeOf returns the category of err based on the sentinel errors and error
+// types it wraps.
+func CodeOf(err error) Code {
+	var depErr *DependencyError
+	var bootErr *BootstrapError
+
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrConfigInvalid):
+		return CodeConfig
+	case errors.Is(err, ErrTelemetrySetup):
+		return CodeTelemetry
+	case errors.Is(err, ErrDependencyTimeout),
+		errors.Is(err, ErrDependencyUnhealthy),
+		errors.As(err, &depErr):
+		return CodeDependencyTimeout
+	case errors.Is(err, ErrBootstrapFailed),
+		errors.Is(err, ErrBootstrapDeadline),
+		errors.As(err, &bootErr):
+		return CodeProvisioning
+	default:
+		return CodeUnknown
+	}
+}
+
 // DependencyError wraps an error with dependency context.
 type DependencyError struct {
 	Service string
@@ -0,0 +1,31 @@
+package errors
+
+// Process exit codes, one per failure category, so orchestrators can react to
+// the cause of a failed start without parsing logs.
+const (
+	ExitOK                = 0
+	ExitUnknown           = 1
+	ExitConfig            = 2
+	ExitDependencyTimeout = 3
+	ExitProvisioning      = 4
+	ExitTelemetry         = 5
+	ExitInterrupted       = 130 // forced stop on a second interrupt, as shells report SIGINT
+)
+
+// ExitCode maps an error to the process exit code for its category.
+func ExitCode(err error) int {
+	switch CodeOf(err) {
+	case "":
+		return ExitOK
+	case CodeConfig:
+		return ExitConfig
+	case CodeDependencyTimeout:
+		return ExitDependencyTimeout
+	case CodeProvisioning:
+		return ExitProvisioning
+	case CodeTelemetry:
+		return ExitTelemetry
+	default:
+		return ExitUnknown
+	}
+}
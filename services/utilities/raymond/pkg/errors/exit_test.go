@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"config", fmt.Errorf("load config: %w", ErrConfigInvalid), ExitConfig},
+		{"dependency timeout", ErrDependencyTimeout, ExitDependencyTimeout},
+		{"critical dependency unhealthy", ErrDependencyUnhealthy, ExitDependencyTimeout},
+		{"dependency error", NewDependencyError("postgres", fmt.Errorf("dial tcp: refused")), ExitDependencyTimeout},
+		{"provisioning", NewBootstrapError("initialize_nats", fmt.Errorf("create stream")), ExitProvisioning},
+		{"bootstrap deadline", fmt.Errorf("%w: exceeded 5m", ErrBootstrapDeadline), ExitProvisioning},
+		{"telemetry", fmt.Errorf("%w: dial collector", ErrTelemetrySetup), ExitTelemetry},
+		{"uncategorized", fmt.Errorf("something else"), ExitUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeConfigTakesPrecedence(t *testing.T) {
+	// A bootstrap phase that failed because of bad config is a config error
+	err := NewBootstrapError("migrate_database", fmt.Errorf("%w: no migrations dir", ErrConfigInvalid))
+	if got := ExitCode(err); got != ExitConfig {
+		t.Errorf("ExitCode = %d, want %d", got, ExitConfig)
+	}
+}
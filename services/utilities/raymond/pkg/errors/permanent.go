@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrPermanent marks failures that retrying cannot fix, such as invalid
+// configuration or denied access. Wrap errors with Permanent and test for
+// them with IsPermanent.
+var ErrPermanent = errors.New("permanent failure")
+
+// PermanentError wraps an error that retrying cannot fix. Its message is that
+// of the wrapped error.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrPermanent) match.
+func (e *PermanentError) Is(target error) bool {
+	return target == ErrPermanent
+}
+
+// Permanent marks err as not worth retrying. It returns nil for a nil err.
+func Permanent(err error) error {
+	if err == nil || errors.Is(err, ErrPermanent) {
+		return err
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent or is a driver
+// error that retrying cannot fix.
+func IsPermanent(err error) bool {
+	return errors.Is(err, ErrPermanent) || IsPermanentDriverError(err)
+}
+
+// permanentPostgresClasses are SQLSTATE classes caused by the request or the
+// credentials rather than the server's current state.
+var permanentPostgresClasses = map[string]bool{
+	"0A": true, // feature not supported
+	"22": true, // data exception
+	"28": true, // invalid authorization specification
+	"3D": true, // invalid catalog name (database does not exist)
+	"3F": true, // invalid schema name
+	"42": true, // syntax error or access rule violation
+}
+
+// permanentRedisPrefixes are Redis error replies for denied access.
+var permanentRedisPrefixes = []string{"NOAUTH", "WRONGPASS", "NOPERM"}
+
+// IsPermanentDriverError classifies common driver errors: Postgres errors in
+// a non-transient SQLSTATE class, Redis authentication and permission errors,
+// TLS certificate verification failures and malformed URLs. Connection
+// failures, timeouts and anything unrecognized are considered retriable.
+func IsPermanentDriverError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && len(pgErr.Code) >= 2 {
+		return permanentPostgresClasses[pgErr.Code[:2]]
+	}
+
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	if errors.As(err, &certErr) || errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return true
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.Op == "parse" {
+		return true
+	}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		msg := e.Error()
+		for _, prefix := range permanentRedisPrefixes {
+			if strings.HasPrefix(msg, prefix+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
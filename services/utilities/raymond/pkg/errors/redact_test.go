@@ -0,0 +1,25 @@
+package errors
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want string
+	}{
+		{"url password", "dial postgres://app:s3cret@db:5432/app", "dial postgres://app:[redacted]@db:5432/app"},
+		{"key value", "auth failed: password=s3cret user=app", "auth failed: password=[redacted] user=app"},
+		{"quoted", `invalid config: api_key: "abc 123"`, "invalid config: api_key: [redacted]"},
+		{"query", "GET /topics?token=abc&tenant=public", "GET /topics?token=[redacted]&tenant=public"},
+		{"nothing secret", "dial tcp 10.0.0.1:4222: connection refused", "dial tcp 10.0.0.1:4222: connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.msg); got != tt.want {
+				t.Errorf("Redact(%q) = %q, want %q", tt.msg, got, tt.want)
+			}
+		})
+	}
+}